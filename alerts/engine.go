@@ -0,0 +1,203 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/streamdp/ccd/config"
+	"github.com/streamdp/ccd/db"
+	"github.com/streamdp/ccd/domain"
+	"github.com/streamdp/ccd/hub"
+	"github.com/streamdp/ccd/webhook"
+)
+
+const (
+	hubBufferSize = 1024
+	// the matches are delivered by the fixed number of workers, the events are dropped when the queue is full
+	deliveryWorkers   = 4
+	deliveryQueueSize = 1024
+)
+
+// delivery of the alert event to the receiver
+type delivery struct {
+	event  *domain.AlertEvent
+	secret string
+}
+
+// ErrNotFound is returned when there is no alert with the selected id
+var ErrNotFound = errors.New("alert not found")
+
+// Engine keeps the alert rules, evaluates them against every tick published to the hub and delivers the matches
+// to the webhook receivers
+type Engine struct {
+	db    db.Database
+	l     *log.Logger
+	h     *hub.Hub
+	s     *webhook.Sender
+	rules map[int64]*rule
+	queue chan delivery
+	mu    sync.Mutex
+}
+
+// NewEngine init alerts engine with the rules stored in the database
+func NewEngine(d db.Database, l *log.Logger, h *hub.Hub) (*Engine, error) {
+	alerts, err := d.Alerts()
+	if err != nil {
+		return nil, err
+	}
+	e := &Engine{
+		db:    d,
+		l:     l,
+		h:     h,
+		s:     webhook.NewSender(time.Duration(config.HttpClientTimeout) * time.Millisecond),
+		rules: make(map[int64]*rule, len(alerts)),
+		queue: make(chan delivery, deliveryQueueSize),
+	}
+	for _, a := range alerts {
+		e.rules[a.Id] = newRule(a)
+	}
+	return e, nil
+}
+
+// Run evaluation of the rules and the delivery workers in the background
+func (e *Engine) Run() {
+	for i := 0; i < deliveryWorkers; i++ {
+		go func() {
+			for d := range e.queue {
+				e.deliver(d.event, d.secret)
+			}
+		}()
+	}
+	sub := e.h.SubscribeAll(hubBufferSize)
+	go func() {
+		for ev := range sub.C() {
+			e.handle(ev.Data)
+		}
+	}()
+}
+
+// List all alerts ordered by id
+func (e *Engine) List() []*domain.Alert {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	alerts := make([]*domain.Alert, 0, len(e.rules))
+	for _, r := range e.rules {
+		a := *r.a
+		alerts = append(alerts, &a)
+	}
+	sort.Slice(alerts, func(i, j int) bool {
+		return alerts[i].Id < alerts[j].Id
+	})
+	return alerts
+}
+
+// Get alert with the selected id
+func (e *Engine) Get(id int64) (*domain.Alert, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	r, ok := e.rules[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	a := *r.a
+	return &a, nil
+}
+
+// Add new alert and start evaluating it
+func (e *Engine) Add(a *domain.Alert) (err error) {
+	if a.Id, err = e.db.AddAlert(a); err != nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rules[a.Id] = newRule(a)
+	return
+}
+
+// Update the alert, its state is reset
+func (e *Engine) Update(a *domain.Alert) (err error) {
+	if _, err = e.Get(a.Id); err != nil {
+		return
+	}
+	if _, err = e.db.UpdateAlert(a); err != nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rules[a.Id] = newRule(a)
+	return
+}
+
+// Remove the alert with the selected id
+func (e *Engine) Remove(id int64) (err error) {
+	if _, err = e.Get(id); err != nil {
+		return
+	}
+	if _, err = e.db.RemoveAlert(id); err != nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.rules, id)
+	return
+}
+
+// Deliveries log of the alert with the selected id, newest first
+func (e *Engine) Deliveries(id int64, limit int) ([]*domain.Delivery, error) {
+	if _, err := e.Get(id); err != nil {
+		return nil, err
+	}
+	return e.db.Deliveries(id, limit)
+}
+
+func (e *Engine) handle(d *domain.Data) {
+	pair := domain.PairName(d.FromSymbol, d.ToSymbol)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, r := range e.rules {
+		if !r.a.Enabled || domain.PairName(r.a.From, r.a.To) != pair {
+			continue
+		}
+		event := r.evaluate(d)
+		if event == nil {
+			continue
+		}
+		select {
+		case e.queue <- delivery{event: event, secret: r.a.Secret}:
+		default:
+			e.l.Printf("alert %d event dropped, the delivery queue is full", r.a.Id)
+		}
+	}
+}
+
+func (e *Engine) deliver(event *domain.AlertEvent, secret string) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		e.l.Println(err)
+		return
+	}
+	a := event.Alert
+	err = e.s.Send(context.Background(), a.Url, secret, body, func(attempt *webhook.Attempt) {
+		l := &domain.Delivery{
+			AlertId:   a.Id,
+			Url:       a.Url,
+			Attempt:   attempt.Number,
+			Status:    attempt.Status,
+			CreatedAt: time.Now().Unix(),
+		}
+		if attempt.Err != nil {
+			l.Error = attempt.Err.Error()
+		}
+		if _, err := e.db.AddDelivery(l); err != nil {
+			e.l.Println(err)
+		}
+	})
+	if err != nil {
+		e.l.Println(err)
+	}
+}
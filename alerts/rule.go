@@ -0,0 +1,127 @@
+package alerts
+
+import (
+	"math"
+	"time"
+
+	"github.com/streamdp/ccd/domain"
+)
+
+const (
+	// hysteresis is the part of the alert value the price should move back beyond the value before the threshold
+	// alert could fire again, so the price jittering around the value doesn't flood the receiver
+	hysteresis = 0.001
+	// cooldown is the minimum time between two events of the threshold alert for the same provider
+	cooldown = time.Minute
+)
+
+type sample struct {
+	t     time.Time
+	price float64
+}
+
+// state of the alert for the ticks of one data provider
+type state struct {
+	// side of the value the price is on: 1 above, -1 below, 0 unknown yet
+	side int
+	// latched is true if the price got to the side by the crossing that fired, it has to move beyond the hysteresis
+	// band to leave the side
+	latched bool
+	// price of the previous tick
+	previous float64
+	// samples of the price within the window of the "change" alert, oldest first
+	samples []sample
+	fired   time.Time
+}
+
+// rule keeps the state of the alert between the ticks, every data provider of the pair has its own state
+type rule struct {
+	a      *domain.Alert
+	states map[string]*state
+}
+
+func newRule(a *domain.Alert) *rule {
+	return &rule{
+		a:      a,
+		states: map[string]*state{},
+	}
+}
+
+// evaluate the tick and return the event if the alert matched
+func (r *rule) evaluate(d *domain.Data) (e *domain.AlertEvent) {
+	if d.Price == 0 {
+		return nil
+	}
+	s, ok := r.states[d.Provider]
+	if !ok {
+		s = &state{}
+		r.states[d.Provider] = s
+	}
+	if r.a.Condition == domain.AlertChange {
+		return r.change(s, d)
+	}
+	previous, dir := r.cross(s, d.Price)
+	switch {
+	case dir == 0:
+		return nil
+	case dir > 0 && r.a.Condition == domain.AlertBelow, dir < 0 && r.a.Condition == domain.AlertAbove:
+		return nil
+	case d.UpdatedAt().Sub(s.fired) < cooldown:
+		return nil
+	}
+	s.fired, s.latched = d.UpdatedAt(), true
+	return r.event(d, previous, 0)
+}
+
+// cross moves the state to the side of the price and return the previous price and the direction of the crossing
+// (1 up, -1 down) or 0
+func (r *rule) cross(s *state, price float64) (previous float64, dir int) {
+	previous, s.previous = s.previous, price
+	band := 0.0
+	if s.latched {
+		band = math.Abs(r.a.Value) * hysteresis
+	}
+	switch {
+	case s.side == 0:
+		if s.side = -1; price >= r.a.Value {
+			s.side = 1
+		}
+	case s.side < 0 && price >= r.a.Value+band:
+		s.side, s.latched, dir = 1, false, 1
+	case s.side > 0 && price <= r.a.Value-band:
+		s.side, s.latched, dir = -1, false, -1
+	}
+	return previous, dir
+}
+
+// change compares the price with the oldest one within the window, the alert fires once per window
+func (r *rule) change(s *state, d *domain.Data) *domain.AlertEvent {
+	now := d.UpdatedAt()
+	window := time.Duration(r.a.Window) * time.Second
+	i := 0
+	for i < len(s.samples) && now.Sub(s.samples[i].t) > window {
+		i++
+	}
+	s.samples = append(s.samples[i:], sample{t: now, price: d.Price})
+	if now.Sub(s.fired) < window {
+		return nil
+	}
+	base := s.samples[0].price
+	pct := (d.Price - base) / base * 100
+	if math.Abs(pct) < r.a.Value {
+		return nil
+	}
+	s.fired = now
+	return r.event(d, base, pct)
+}
+
+func (r *rule) event(d *domain.Data, previous, change float64) *domain.AlertEvent {
+	a := *r.a
+	return &domain.AlertEvent{
+		Alert:    &a,
+		Data:     d,
+		Previous: previous,
+		Change:   change,
+		Time:     time.Now().Unix(),
+	}
+}
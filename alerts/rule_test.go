@@ -0,0 +1,99 @@
+package alerts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/streamdp/ccd/domain"
+)
+
+const start = 1705313045000
+
+func tick(provider string, price float64, after time.Duration) *domain.Data {
+	return &domain.Data{FromSymbol: "BTC", ToSymbol: "USD", Price: price, Provider: provider,
+		LastUpdate: start + after.Milliseconds()}
+}
+
+func TestCrossesHysteresis(t *testing.T) {
+	r := newRule(&domain.Alert{Condition: domain.AlertCrosses, Value: 70000})
+	tests := []struct {
+		price float64
+		after time.Duration
+		fire  bool
+	}{
+		{69000, 0, false},
+		{70000, time.Second, true},
+		// back below the value but within the hysteresis band
+		{69950, 2 * time.Minute, false},
+		{70010, 3 * time.Minute, false},
+		// beyond the band
+		{69900, 4 * time.Minute, true},
+		{70000, 5 * time.Minute, false},
+		{70100, 6 * time.Minute, true},
+	}
+	for i, tt := range tests {
+		if e := r.evaluate(tick("binance", tt.price, tt.after)); (e != nil) != tt.fire {
+			t.Errorf("tick %d: got event %v, want %v", i, e != nil, tt.fire)
+		}
+	}
+}
+
+func TestCooldown(t *testing.T) {
+	r := newRule(&domain.Alert{Condition: domain.AlertAbove, Value: 100})
+	tests := []struct {
+		price float64
+		after time.Duration
+		fire  bool
+	}{
+		{99, 0, false},
+		{101, time.Second, true},
+		{99, 2 * time.Second, false},
+		// re-armed, but within the cooldown
+		{101, 3 * time.Second, false},
+		{99, 2 * time.Minute, false},
+		{101, 2*time.Minute + time.Second, true},
+	}
+	for i, tt := range tests {
+		e := r.evaluate(tick("binance", tt.price, tt.after))
+		if (e != nil) != tt.fire {
+			t.Errorf("tick %d: got event %v, want %v", i, e != nil, tt.fire)
+		}
+		if e != nil && e.Previous != 99 {
+			t.Errorf("tick %d: got previous price %v, want 99", i, e.Previous)
+		}
+	}
+}
+
+func TestStatePerProvider(t *testing.T) {
+	r := newRule(&domain.Alert{Condition: domain.AlertAbove, Value: 100})
+	// the ticks of the providers interleave, a crossing is only seen within the ticks of the same provider
+	if r.evaluate(tick("binance", 99, 0)) != nil || r.evaluate(tick("kraken", 101, 0)) != nil {
+		t.Error("the first ticks fired")
+	}
+	if r.evaluate(tick("binance", 99.5, time.Second)) != nil {
+		t.Error("binance fired below the value")
+	}
+	if r.evaluate(tick("kraken", 102, time.Second)) != nil {
+		t.Error("kraken fired without the crossing")
+	}
+	if r.evaluate(tick("binance", 100.5, 2*time.Second)) == nil {
+		t.Error("binance crossing didn't fire")
+	}
+}
+
+func TestChange(t *testing.T) {
+	r := newRule(&domain.Alert{Condition: domain.AlertChange, Value: 5, Window: 3600})
+	if r.evaluate(tick("binance", 100, 0)) != nil || r.evaluate(tick("kraken", 200, 0)) != nil {
+		t.Error("the first ticks fired")
+	}
+	e := r.evaluate(tick("binance", 106, time.Minute))
+	if e == nil || e.Previous != 100 || e.Change != 6 {
+		t.Errorf("got event %+v, want 6%% change from 100", e)
+	}
+	if e = r.evaluate(tick("binance", 120, 2*time.Minute)); e != nil {
+		t.Error("fired twice within the window")
+	}
+	if e = r.evaluate(tick("kraken", 205, time.Minute)); e != nil {
+		t.Error("kraken fired on 2.5% change")
+	}
+}
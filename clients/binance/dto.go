@@ -0,0 +1,53 @@
+package binance
+
+type binanceRestData struct {
+	Code               int     `json:"code"`
+	Msg                string  `json:"msg"`
+	Symbol             string  `json:"symbol"`
+	PriceChange        float64 `json:"priceChange,string"`
+	PriceChangePercent float64 `json:"priceChangePercent,string"`
+	LastPrice          float64 `json:"lastPrice,string"`
+	BidPrice           float64 `json:"bidPrice,string"`
+	AskPrice           float64 `json:"askPrice,string"`
+	OpenPrice          float64 `json:"openPrice,string"`
+	HighPrice          float64 `json:"highPrice,string"`
+	LowPrice           float64 `json:"lowPrice,string"`
+	Volume             float64 `json:"volume,string"`
+	QuoteVolume        float64 `json:"quoteVolume,string"`
+	OpenTime           int64   `json:"openTime"`
+	CloseTime          int64   `json:"closeTime"`
+	Count              int64   `json:"count"`
+}
+
+type binanceWsData struct {
+	Stream string          `json:"stream"`
+	Data   binanceWsTicker `json:"data"`
+}
+
+// binanceWsTicker keys are case-sensitive ("c" is the last price, "C" is the close time), so all of them are declared
+// to prevent encoding/json from matching the keys case-insensitively
+type binanceWsTicker struct {
+	EventType          string  `json:"e"`
+	EventTime          int64   `json:"E"`
+	Symbol             string  `json:"s"`
+	PriceChange        float64 `json:"p,string"`
+	PriceChangePercent float64 `json:"P,string"`
+	WeightedAvgPrice   string  `json:"w"`
+	FirstTradePrice    string  `json:"x"`
+	LastPrice          float64 `json:"c,string"`
+	LastQty            string  `json:"Q"`
+	BidPrice           string  `json:"b"`
+	BidQty             string  `json:"B"`
+	AskPrice           string  `json:"a"`
+	AskQty             string  `json:"A"`
+	OpenPrice          float64 `json:"o,string"`
+	HighPrice          float64 `json:"h,string"`
+	LowPrice           float64 `json:"l,string"`
+	Volume             float64 `json:"v,string"`
+	QuoteVolume        float64 `json:"q,string"`
+	OpenTime           int64   `json:"O"`
+	CloseTime          int64   `json:"C"`
+	FirstTradeId       int64   `json:"F"`
+	LastTradeId        int64   `json:"L"`
+	Count              int64   `json:"n"`
+}
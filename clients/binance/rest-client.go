@@ -0,0 +1,129 @@
+package binance
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/streamdp/ccd/clients"
+	"github.com/streamdp/ccd/clients/recorder"
+	"github.com/streamdp/ccd/config"
+	"github.com/streamdp/ccd/domain"
+)
+
+const (
+	// Name of the data provider
+	Name = "binance"
+
+	apiUrl = "https://api.binance.com"
+
+	// 24hr Ticker Price Change Statistics
+	// https://binance-docs.github.io/apidocs/spot/en/#24hr-ticker-price-change-statistics
+	// 24 hour rolling window price change statistics.
+	// Request Parameters "symbol" (e.g. BTCUSDT)
+	tickerPriceChangeStatistics = "/api/v3/ticker/24hr"
+)
+
+type binanceRest struct {
+	apiUrl string
+	client *http.Client
+	rec    *recorder.Recorder
+}
+
+func Init(rec *recorder.Recorder) (clients.RestClient, error) {
+	return &binanceRest{
+		apiUrl: apiUrl,
+		client: &http.Client{
+			Timeout: time.Duration(config.HttpClientTimeout) * time.Millisecond,
+		},
+		rec: rec,
+	}, nil
+}
+
+func (b *binanceRest) Get(fSym string, tSym string) (ds *domain.Data, err error) {
+	var (
+		u        *url.URL
+		response *http.Response
+		body     []byte
+	)
+	if u, err = b.buildURL(fSym, tSym); err != nil {
+		return nil, err
+	}
+	if response, err = b.client.Get(u.String()); err != nil {
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(response.Body)
+	if body, err = io.ReadAll(response.Body); err != nil {
+		return nil, err
+	}
+	b.rec.Record(Name, recorder.Rest, body)
+	rawData := &binanceRestData{}
+	if err = json.Unmarshal(body, rawData); err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		if rawData.Msg != "" {
+			return nil, errors.New(rawData.Msg)
+		}
+		return nil, fmt.Errorf("unexpected status code %d", response.StatusCode)
+	}
+	return convertBinanceRestDataToDomain(fSym, tSym, rawData), nil
+}
+
+func convertBinanceRestDataToDomain(from, to string, d *binanceRestData) *domain.Data {
+	if d == nil {
+		return nil
+	}
+	b, _ := json.Marshal(&domain.Raw{
+		FromSymbol:      from,
+		ToSymbol:        to,
+		Change24Hour:    d.PriceChange,
+		ChangePct24Hour: d.PriceChangePercent,
+		Open24Hour:      d.OpenPrice,
+		Volume24Hour:    d.Volume,
+		Volume24HourTo:  d.QuoteVolume,
+		Low24Hour:       d.LowPrice,
+		High24Hour:      d.HighPrice,
+		Price:           d.LastPrice,
+		LastUpdate:      d.CloseTime,
+	})
+	return &domain.Data{
+		FromSymbol:      from,
+		ToSymbol:        to,
+		Change24Hour:    d.PriceChange,
+		ChangePct24Hour: d.PriceChangePercent,
+		Open24Hour:      d.OpenPrice,
+		Volume24Hour:    d.Volume,
+		Low24Hour:       d.LowPrice,
+		High24Hour:      d.HighPrice,
+		Price:           d.LastPrice,
+		LastUpdate:      d.CloseTime,
+		DisplayDataRaw:  string(b),
+		Provider:        Name,
+	}
+}
+
+func (b *binanceRest) buildURL(fSym string, tSym string) (u *url.URL, err error) {
+	if u, err = url.Parse(b.apiUrl + tickerPriceChangeStatistics); err != nil {
+		return nil, err
+	}
+	query := u.Query()
+	query.Set("symbol", buildSymbol(fSym, tSym))
+	u.RawQuery = query.Encode()
+	return u, nil
+}
+
+// buildSymbol return binance symbol for the currencies pair, binance has no USD pairs, so USDT is used instead
+func buildSymbol(from, to string) string {
+	if strings.ToLower(to) == "usd" {
+		to = "usdt"
+	}
+	return strings.ToUpper(from + to)
+}
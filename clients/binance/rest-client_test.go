@@ -0,0 +1,79 @@
+package binance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const restTicker = `{"symbol":"BTCUSDT","priceChange":"-94.99999800","priceChangePercent":"-95.960",` +
+	`"lastPrice":"4.00000200","bidPrice":"4.00000000","askPrice":"4.00000200","openPrice":"99.00000000",` +
+	`"highPrice":"100.00000000","lowPrice":"0.10000000","volume":"8913.30000000","quoteVolume":"15.30000000",` +
+	`"openTime":1499783499040,"closeTime":1499869899040,"count":76}`
+
+func newTestRest(t *testing.T, status int, body string) (*binanceRest, *http.Request) {
+	var req http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = *r
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return &binanceRest{apiUrl: srv.URL, client: srv.Client()}, &req
+}
+
+func TestRestGet(t *testing.T) {
+	b, req := newTestRest(t, http.StatusOK, restTicker)
+	d, err := b.Get("btc", "usd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.URL.Path != tickerPriceChangeStatistics || req.URL.Query().Get("symbol") != "BTCUSDT" {
+		t.Errorf("got request %s, want %s?symbol=BTCUSDT", req.URL, tickerPriceChangeStatistics)
+	}
+	if d.FromSymbol != "btc" || d.ToSymbol != "usd" || d.Provider != Name {
+		t.Errorf("got pair %s:%s of %s, want btc:usd of %s", d.FromSymbol, d.ToSymbol, d.Provider, Name)
+	}
+	if d.Price != 4.000002 || d.Open24Hour != 99 || d.High24Hour != 100 || d.Low24Hour != 0.1 ||
+		d.Volume24Hour != 8913.3 || d.Change24Hour != -94.999998 || d.LastUpdate != 1499869899040 {
+		t.Errorf("got unexpected data %+v", d)
+	}
+	if d.DisplayDataRaw == "" {
+		t.Error("display data is empty")
+	}
+}
+
+func TestRestGetError(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   string
+	}{
+		{"binance error", http.StatusBadRequest, `{"code":-1121,"msg":"Invalid symbol."}`, "Invalid symbol."},
+		{"status code", http.StatusBadGateway, `{}`, "unexpected status code 502"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, _ := newTestRest(t, tt.status, tt.body)
+			if _, err := b.Get("XXX", "USD"); err == nil || err.Error() != tt.want {
+				t.Errorf("got error %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildSymbol(t *testing.T) {
+	tests := []struct {
+		from, to, want string
+	}{
+		{"btc", "usd", "BTCUSDT"},
+		{"ETH", "BTC", "ETHBTC"},
+		{"eth", "usdt", "ETHUSDT"},
+	}
+	for _, tt := range tests {
+		if got := buildSymbol(tt.from, tt.to); got != tt.want {
+			t.Errorf("buildSymbol(%s, %s) = %s, want %s", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/streamdp/ccd/domain"
+	"nhooyr.io/websocket"
+
+	"github.com/streamdp/ccd/clients"
+	"github.com/streamdp/ccd/clients/recorder"
+)
+
+const wssUrl = "wss://stream.binance.com:9443/stream"
+
+type binanceWs struct {
+	ctx        context.Context
+	l          *log.Logger
+	rec        *recorder.Recorder
+	conn       *websocket.Conn
+	wssUrl     string
+	subscribes domain.Subscribes
+	subMu      sync.RWMutex
+}
+
+func InitWs(pipe chan *domain.Data, l *log.Logger, rec *recorder.Recorder) (clients.WsClient, error) {
+	b := &binanceWs{
+		ctx:        context.Background(),
+		l:          l,
+		rec:        rec,
+		wssUrl:     wssUrl,
+		subscribes: domain.Subscribes{},
+	}
+	if err := b.reconnect(); err != nil {
+		return nil, err
+	}
+	b.handleWsMessages(pipe)
+	return b, nil
+}
+
+func (b *binanceWs) reconnect() (err error) {
+	if b.conn != nil {
+		if err := b.conn.Close(websocket.StatusNormalClosure, ""); err != nil {
+			b.l.Println(err)
+			// reducing logs and CPU load when the server is unavailable
+			time.Sleep(10 * time.Second)
+		}
+	}
+	b.conn, _, err = websocket.Dial(b.ctx, b.wssUrl, nil)
+	return
+}
+
+func (b *binanceWs) resubscribe() (err error) {
+	b.subMu.RLock()
+	defer b.subMu.RUnlock()
+	for k, v := range b.subscribes {
+		if err = b.sendSubscribeMsg(k, v.Id()); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (b *binanceWs) handleWsError(err error) error {
+	b.l.Println(err)
+	for {
+		select {
+		case <-time.After(time.Minute):
+			return errors.New("reconnect failed")
+		default:
+			if err = b.reconnect(); err != nil {
+				time.Sleep(time.Second)
+				continue
+			}
+			if err = b.resubscribe(); err != nil {
+				time.Sleep(time.Second)
+				continue
+			}
+			return nil
+		}
+	}
+}
+
+func (b *binanceWs) handleWsMessages(pipe chan *domain.Data) {
+	go func() {
+		defer func(conn *websocket.Conn, code websocket.StatusCode, reason string) {
+			if err := conn.Close(code, reason); err != nil {
+				b.l.Println(err)
+			}
+		}(b.conn, websocket.StatusNormalClosure, "")
+		for {
+			select {
+			case <-b.ctx.Done():
+				return
+			default:
+				var (
+					body []byte
+					err  error
+				)
+				// binance sends ping frames every 3 minutes, the websocket library answers them while reading
+				if _, body, err = b.conn.Read(b.ctx); err != nil {
+					if err = b.handleWsError(err); err != nil {
+						b.l.Println(err)
+						return
+					}
+					continue
+				}
+				b.rec.Record(Name, recorder.Ws, body)
+				data := &binanceWsData{}
+				if err = json.Unmarshal(body, data); err != nil {
+					b.l.Println(err)
+					continue
+				}
+				// responses to the subscribe/unsubscribe requests have no stream name
+				if data.Stream == "" {
+					continue
+				}
+				from, to := b.pairFromChannelName(data.Stream)
+				if from != "" && to != "" {
+					pipe <- convertBinanceWsDataToDomain(from, to, &data.Data)
+				}
+			}
+		}
+	}()
+}
+
+func (b *binanceWs) pairFromChannelName(ch string) (from, to string) {
+	b.subMu.RLock()
+	defer b.subMu.RUnlock()
+	if c, ok := b.subscribes[ch]; ok {
+		return c.From, c.To
+	}
+	return
+}
+
+func buildChannelName(from, to string) string {
+	return fmt.Sprintf("%s@ticker", strings.ToLower(buildSymbol(from, to)))
+}
+
+func (b *binanceWs) Unsubscribe(from, to string) (err error) {
+	b.subMu.Lock()
+	defer b.subMu.Unlock()
+	var ch = buildChannelName(from, to)
+	if c, ok := b.subscribes[ch]; ok {
+		if err = b.sendUnsubscribeMsg(ch, c.Id()); err != nil {
+			return
+		}
+		delete(b.subscribes, ch)
+	}
+	return
+}
+
+func (b *binanceWs) sendUnsubscribeMsg(ch string, id int64) error {
+	return b.conn.Write(b.ctx, websocket.MessageText, []byte(
+		fmt.Sprintf("{\"method\":\"UNSUBSCRIBE\",\"params\":[\"%s\"],\"id\":%d}", ch, id)),
+	)
+}
+
+func (b *binanceWs) Subscribe(from, to string) (err error) {
+	b.subMu.Lock()
+	defer b.subMu.Unlock()
+	var (
+		id = time.Now().UnixMilli()
+		ch = buildChannelName(from, to)
+	)
+	if err = b.sendSubscribeMsg(ch, id); err != nil {
+		return
+	}
+	b.subscribes[ch] = domain.NewSubscribe(from, to, id)
+	return
+}
+
+func (b *binanceWs) sendSubscribeMsg(ch string, id int64) error {
+	return b.conn.Write(b.ctx, websocket.MessageText, []byte(
+		fmt.Sprintf("{\"method\":\"SUBSCRIBE\",\"params\":[\"%s\"],\"id\":%d}", ch, id)),
+	)
+}
+
+func (b *binanceWs) ListSubscribes() domain.Subscribes {
+	s := make(domain.Subscribes, len(b.subscribes))
+	b.subMu.RLock()
+	defer b.subMu.RUnlock()
+	for k, v := range b.subscribes {
+		s[k] = v
+	}
+	return s
+}
+
+func convertBinanceWsDataToDomain(from, to string, d *binanceWsTicker) *domain.Data {
+	if d == nil {
+		return nil
+	}
+	b, _ := json.Marshal(&domain.Raw{
+		FromSymbol:      from,
+		ToSymbol:        to,
+		Change24Hour:    d.PriceChange,
+		ChangePct24Hour: d.PriceChangePercent,
+		Open24Hour:      d.OpenPrice,
+		Volume24Hour:    d.Volume,
+		Volume24HourTo:  d.QuoteVolume,
+		Low24Hour:       d.LowPrice,
+		High24Hour:      d.HighPrice,
+		Price:           d.LastPrice,
+		LastUpdate:      d.EventTime,
+	})
+	return &domain.Data{
+		FromSymbol:      from,
+		ToSymbol:        to,
+		Change24Hour:    d.PriceChange,
+		ChangePct24Hour: d.PriceChangePercent,
+		Open24Hour:      d.OpenPrice,
+		Volume24Hour:    d.Volume,
+		Low24Hour:       d.LowPrice,
+		High24Hour:      d.HighPrice,
+		Price:           d.LastPrice,
+		LastUpdate:      d.EventTime,
+		DisplayDataRaw:  string(b),
+		Provider:        Name,
+	}
+}
@@ -0,0 +1,111 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/streamdp/ccd/domain"
+	"nhooyr.io/websocket"
+)
+
+const wsTicker = `{"stream":"btcusdt@ticker","data":{"e":"24hrTicker","E":1672515782136,"s":"BTCUSDT",` +
+	`"p":"0.0015","P":"250.00","w":"0.0018","x":"0.0009","c":"0.0025","Q":"10","b":"0.0024","B":"10",` +
+	`"a":"0.0026","A":"100","o":"0.0010","h":"0.0025","l":"0.0010","v":"10000","q":"18","O":0,"C":86400000,` +
+	`"F":0,"L":18150,"n":18151}}`
+
+type request struct {
+	Method string   `json:"method"`
+	Params []string `json:"params"`
+	Id     int64    `json:"id"`
+}
+
+// newTestWs connects the client to the stand-in server, the server sends the ticker after every subscribe request
+// and passes all the requests to the channel
+func newTestWs(t *testing.T) (*binanceWs, chan *domain.Data, chan request) {
+	requests := make(chan request, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer func() {
+			_ = conn.Close(websocket.StatusNormalClosure, "")
+		}()
+		for {
+			_, b, err := conn.Read(r.Context())
+			if err != nil {
+				return
+			}
+			req := request{}
+			if err = json.Unmarshal(b, &req); err != nil {
+				t.Error(err)
+				return
+			}
+			requests <- req
+			if err = conn.Write(r.Context(), websocket.MessageText, []byte(`{"result":null,"id":1}`)); err != nil {
+				return
+			}
+			if req.Method == "SUBSCRIBE" {
+				if err = conn.Write(r.Context(), websocket.MessageText, []byte(wsTicker)); err != nil {
+					return
+				}
+			}
+		}
+	}))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(func() {
+		cancel()
+		srv.Close()
+	})
+	b := &binanceWs{
+		ctx:        ctx,
+		l:          log.New(&strings.Builder{}, "", 0),
+		wssUrl:     "ws" + strings.TrimPrefix(srv.URL, "http"),
+		subscribes: domain.Subscribes{},
+	}
+	if err := b.reconnect(); err != nil {
+		t.Fatal(err)
+	}
+	pipe := make(chan *domain.Data, 10)
+	b.handleWsMessages(pipe)
+	return b, pipe, requests
+}
+
+func TestWsSubscribe(t *testing.T) {
+	b, pipe, requests := newTestWs(t)
+	if err := b.Subscribe("BTC", "USD"); err != nil {
+		t.Fatal(err)
+	}
+	if req := <-requests; req.Method != "SUBSCRIBE" || len(req.Params) != 1 || req.Params[0] != "btcusdt@ticker" {
+		t.Errorf("got request %+v, want subscribe to btcusdt@ticker", req)
+	}
+	if _, ok := b.ListSubscribes()["btcusdt@ticker"]; !ok {
+		t.Error("subscribe is not listed")
+	}
+	select {
+	case d := <-pipe:
+		if d.FromSymbol != "BTC" || d.ToSymbol != "USD" || d.Price != 0.0025 || d.Volume24Hour != 10000 ||
+			d.LastUpdate != 1672515782136 || d.Provider != Name {
+			t.Errorf("got unexpected data %+v", d)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no data received")
+	}
+
+	if err := b.Unsubscribe("BTC", "USD"); err != nil {
+		t.Fatal(err)
+	}
+	if req := <-requests; req.Method != "UNSUBSCRIBE" || len(req.Params) != 1 || req.Params[0] != "btcusdt@ticker" {
+		t.Errorf("got request %+v, want unsubscribe from btcusdt@ticker", req)
+	}
+	if len(b.ListSubscribes()) != 0 {
+		t.Error("subscribe is still listed")
+	}
+}
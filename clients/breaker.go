@@ -0,0 +1,45 @@
+package clients
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	breakerThreshold = 3
+	breakerCooldown  = 30 * time.Second
+)
+
+// breaker opens after breakerThreshold consecutive failures and rejects calls for the breakerCooldown, after that it
+// lets one call through to probe the data provider and closes again if the call succeeded
+type breaker struct {
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < breakerThreshold {
+		return true
+	}
+	if time.Now().Before(b.openUntil) {
+		return false
+	}
+	// half-open state, keep the breaker open for other calls while the probe is running
+	b.openUntil = time.Now().Add(breakerCooldown)
+	return true
+}
+
+func (b *breaker) report(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil {
+		b.failures = 0
+		return
+	}
+	if b.failures++; b.failures >= breakerThreshold {
+		b.openUntil = time.Now().Add(breakerCooldown)
+	}
+}
@@ -0,0 +1,44 @@
+package coinbase
+
+import "time"
+
+type coinbaseTicker struct {
+	Message string    `json:"message"`
+	TradeId int64     `json:"trade_id"`
+	Price   float64   `json:"price,string"`
+	Size    float64   `json:"size,string"`
+	Bid     float64   `json:"bid,string"`
+	Ask     float64   `json:"ask,string"`
+	Volume  float64   `json:"volume,string"`
+	Time    time.Time `json:"time"`
+}
+
+type coinbaseStats struct {
+	Message     string  `json:"message"`
+	Open        float64 `json:"open,string"`
+	High        float64 `json:"high,string"`
+	Low         float64 `json:"low,string"`
+	Last        float64 `json:"last,string"`
+	Volume      float64 `json:"volume,string"`
+	Volume30Day float64 `json:"volume_30day,string"`
+}
+
+type coinbaseWsData struct {
+	Type      string    `json:"type"`
+	Message   string    `json:"message"`
+	Reason    string    `json:"reason"`
+	Sequence  int64     `json:"sequence"`
+	ProductId string    `json:"product_id"`
+	Price     float64   `json:"price,string"`
+	Open24h   float64   `json:"open_24h,string"`
+	Volume24h float64   `json:"volume_24h,string"`
+	Low24h    float64   `json:"low_24h,string"`
+	High24h   float64   `json:"high_24h,string"`
+	Volume30d float64   `json:"volume_30d,string"`
+	BestBid   float64   `json:"best_bid,string"`
+	BestAsk   float64   `json:"best_ask,string"`
+	Side      string    `json:"side"`
+	Time      time.Time `json:"time"`
+	TradeId   int64     `json:"trade_id"`
+	LastSize  float64   `json:"last_size,string"`
+}
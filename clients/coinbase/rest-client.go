@@ -0,0 +1,133 @@
+package coinbase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/streamdp/ccd/clients"
+	"github.com/streamdp/ccd/clients/recorder"
+	"github.com/streamdp/ccd/config"
+	"github.com/streamdp/ccd/domain"
+)
+
+const (
+	// Name of the data provider
+	Name = "coinbase"
+
+	apiUrl = "https://api.exchange.coinbase.com"
+
+	// Get product ticker https://docs.cloud.coinbase.com/exchange/reference/exchangerestapi_getproductticker
+	// Gets snapshot information about the last trade (tick), best bid/ask and 24h volume.
+	productTicker = "/products/%s/ticker"
+
+	// Get product stats https://docs.cloud.coinbase.com/exchange/reference/exchangerestapi_getproductstats
+	// Gets 30day and 24hour stats for a product.
+	productStats = "/products/%s/stats"
+)
+
+type coinbaseRest struct {
+	apiUrl string
+	client *http.Client
+	rec    *recorder.Recorder
+}
+
+func Init(rec *recorder.Recorder) (clients.RestClient, error) {
+	return &coinbaseRest{
+		apiUrl: apiUrl,
+		client: &http.Client{
+			Timeout: time.Duration(config.HttpClientTimeout) * time.Millisecond,
+		},
+		rec: rec,
+	}, nil
+}
+
+func (c *coinbaseRest) Get(fSym string, tSym string) (ds *domain.Data, err error) {
+	var (
+		id     = buildProductId(fSym, tSym)
+		ticker = &coinbaseTicker{}
+		stats  = &coinbaseStats{}
+	)
+	if err = c.get(fmt.Sprintf(productTicker, id), ticker); err != nil {
+		return nil, err
+	}
+	if err = c.get(fmt.Sprintf(productStats, id), stats); err != nil {
+		return nil, err
+	}
+	return convertCoinbaseRestDataToDomain(fSym, tSym, ticker, stats), nil
+}
+
+func (c *coinbaseRest) get(path string, v interface{}) (err error) {
+	var (
+		response *http.Response
+		body     []byte
+	)
+	if response, err = c.client.Get(c.apiUrl + path); err != nil {
+		return err
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(response.Body)
+	if body, err = io.ReadAll(response.Body); err != nil {
+		return err
+	}
+	c.rec.Record(Name, recorder.Rest, body)
+	if response.StatusCode != http.StatusOK {
+		msg := &struct {
+			Message string `json:"message"`
+		}{}
+		if err = json.Unmarshal(body, msg); err == nil && msg.Message != "" {
+			return errors.New(msg.Message)
+		}
+		return fmt.Errorf("unexpected status code %d", response.StatusCode)
+	}
+	return json.Unmarshal(body, v)
+}
+
+func convertCoinbaseRestDataToDomain(from, to string, t *coinbaseTicker, s *coinbaseStats) *domain.Data {
+	if t == nil || s == nil {
+		return nil
+	}
+	var (
+		change    = t.Price - s.Open
+		changePct float64
+	)
+	if s.Open != 0 {
+		changePct = change / s.Open * 100
+	}
+	b, _ := json.Marshal(&domain.Raw{
+		FromSymbol:      from,
+		ToSymbol:        to,
+		Change24Hour:    change,
+		ChangePct24Hour: changePct,
+		Open24Hour:      s.Open,
+		Volume24Hour:    s.Volume,
+		Low24Hour:       s.Low,
+		High24Hour:      s.High,
+		Price:           t.Price,
+		LastUpdate:      t.Time.UnixMilli(),
+	})
+	return &domain.Data{
+		FromSymbol:      from,
+		ToSymbol:        to,
+		Change24Hour:    change,
+		ChangePct24Hour: changePct,
+		Open24Hour:      s.Open,
+		Volume24Hour:    s.Volume,
+		Low24Hour:       s.Low,
+		High24Hour:      s.High,
+		Price:           t.Price,
+		LastUpdate:      t.Time.UnixMilli(),
+		DisplayDataRaw:  string(b),
+		Provider:        Name,
+	}
+}
+
+// buildProductId return coinbase product id for the currencies pair, e.g. BTC-USD
+func buildProductId(from, to string) string {
+	return strings.ToUpper(from + "-" + to)
+}
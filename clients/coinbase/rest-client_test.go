@@ -0,0 +1,79 @@
+package coinbase
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newTestRest serves the recorded responses from the testdata directory by the endpoint path
+func newTestRest(t *testing.T, status int, files map[string]string) *coinbaseRest {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, ok := files[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"NotFound"}`))
+			return
+		}
+		b, err := os.ReadFile(filepath.Join("testdata", name))
+		if err != nil {
+			t.Error(err)
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write(b)
+	}))
+	t.Cleanup(srv.Close)
+	return &coinbaseRest{apiUrl: srv.URL, client: srv.Client()}
+}
+
+func TestRestGet(t *testing.T) {
+	c := newTestRest(t, http.StatusOK, map[string]string{
+		"/products/BTC-USD/ticker": "ticker.json",
+		"/products/BTC-USD/stats":  "stats.json",
+	})
+	d, err := c.Get("btc", "usd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.FromSymbol != "btc" || d.ToSymbol != "usd" || d.Provider != Name {
+		t.Errorf("got pair %s:%s of %s, want btc:usd of %s", d.FromSymbol, d.ToSymbol, d.Provider, Name)
+	}
+	if d.Price != 43183.33 || d.Open24Hour != 42500.01 || d.High24Hour != 43500 || d.Low24Hour != 42100.5 ||
+		d.Volume24Hour != 12345.67890123 {
+		t.Errorf("got unexpected data %+v", d)
+	}
+	if math.Abs(d.Change24Hour-683.32) > 1e-9 || math.Abs(d.ChangePct24Hour-1.6078114) > 1e-6 {
+		t.Errorf("got change %v (%v%%), want 683.32 (1.6078114%%)", d.Change24Hour, d.ChangePct24Hour)
+	}
+	// the ticker time has the microsecond precision, the milliseconds must be kept
+	if d.LastUpdate != 1705313045123 {
+		t.Errorf("got last update %d, want 1705313045123", d.LastUpdate)
+	}
+	if d.DisplayDataRaw == "" {
+		t.Error("display data is empty")
+	}
+}
+
+func TestRestGetError(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		files  map[string]string
+		want   string
+	}{
+		{"coinbase error", http.StatusOK, map[string]string{}, "NotFound"},
+		{"status code", http.StatusBadGateway, map[string]string{"/products/XXX-USD/ticker": "stats.json"},
+			"unexpected status code 502"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestRest(t, tt.status, tt.files)
+			if _, err := c.Get("XXX", "USD"); err == nil || err.Error() != tt.want {
+				t.Errorf("got error %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package coinbase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/streamdp/ccd/domain"
+	"nhooyr.io/websocket"
+
+	"github.com/streamdp/ccd/clients"
+	"github.com/streamdp/ccd/clients/recorder"
+)
+
+const wssUrl = "wss://ws-feed.exchange.coinbase.com"
+
+type coinbaseWs struct {
+	ctx        context.Context
+	l          *log.Logger
+	rec        *recorder.Recorder
+	conn       *websocket.Conn
+	wssUrl     string
+	subscribes domain.Subscribes
+	subMu      sync.RWMutex
+}
+
+func InitWs(pipe chan *domain.Data, l *log.Logger, rec *recorder.Recorder) (clients.WsClient, error) {
+	c := &coinbaseWs{
+		ctx:        context.Background(),
+		l:          l,
+		rec:        rec,
+		wssUrl:     wssUrl,
+		subscribes: domain.Subscribes{},
+	}
+	if err := c.reconnect(); err != nil {
+		return nil, err
+	}
+	c.handleWsMessages(pipe)
+	return c, nil
+}
+
+func (c *coinbaseWs) reconnect() (err error) {
+	if c.conn != nil {
+		if err := c.conn.Close(websocket.StatusNormalClosure, ""); err != nil {
+			c.l.Println(err)
+			// reducing logs and CPU load when the server is unavailable
+			time.Sleep(10 * time.Second)
+		}
+	}
+	c.conn, _, err = websocket.Dial(c.ctx, c.wssUrl, nil)
+	return
+}
+
+func (c *coinbaseWs) resubscribe() (err error) {
+	c.subMu.RLock()
+	defer c.subMu.RUnlock()
+	for k := range c.subscribes {
+		if err = c.sendSubscribeMsg(k); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (c *coinbaseWs) handleWsError(err error) error {
+	c.l.Println(err)
+	for {
+		select {
+		case <-time.After(time.Minute):
+			return errors.New("reconnect failed")
+		default:
+			if err = c.reconnect(); err != nil {
+				time.Sleep(time.Second)
+				continue
+			}
+			if err = c.resubscribe(); err != nil {
+				time.Sleep(time.Second)
+				continue
+			}
+			return nil
+		}
+	}
+}
+
+func (c *coinbaseWs) handleWsMessages(pipe chan *domain.Data) {
+	go func() {
+		defer func(conn *websocket.Conn, code websocket.StatusCode, reason string) {
+			if err := conn.Close(code, reason); err != nil {
+				c.l.Println(err)
+			}
+		}(c.conn, websocket.StatusNormalClosure, "")
+		for {
+			select {
+			case <-c.ctx.Done():
+				return
+			default:
+				var (
+					body []byte
+					err  error
+				)
+				if _, body, err = c.conn.Read(c.ctx); err != nil {
+					if err = c.handleWsError(err); err != nil {
+						c.l.Println(err)
+						return
+					}
+					continue
+				}
+				c.rec.Record(Name, recorder.Ws, body)
+				data := &coinbaseWsData{}
+				if err = json.Unmarshal(body, data); err != nil {
+					c.l.Println(err)
+					continue
+				}
+				switch data.Type {
+				case "error":
+					c.l.Printf("coinbase error: %s %s", data.Message, data.Reason)
+				case "ticker":
+					from, to := c.pairFromChannelName(data.ProductId)
+					if from != "" && to != "" {
+						pipe <- convertCoinbaseWsDataToDomain(from, to, data)
+					}
+				}
+			}
+		}
+	}()
+}
+
+func (c *coinbaseWs) pairFromChannelName(ch string) (from, to string) {
+	c.subMu.RLock()
+	defer c.subMu.RUnlock()
+	if s, ok := c.subscribes[ch]; ok {
+		return s.From, s.To
+	}
+	return
+}
+
+func (c *coinbaseWs) Unsubscribe(from, to string) (err error) {
+	c.subMu.Lock()
+	defer c.subMu.Unlock()
+	var ch = buildProductId(from, to)
+	if _, ok := c.subscribes[ch]; ok {
+		if err = c.sendUnsubscribeMsg(ch); err != nil {
+			return
+		}
+		delete(c.subscribes, ch)
+	}
+	return
+}
+
+func (c *coinbaseWs) sendUnsubscribeMsg(ch string) error {
+	return c.conn.Write(c.ctx, websocket.MessageText, []byte(
+		fmt.Sprintf("{\"type\":\"unsubscribe\",\"product_ids\":[\"%s\"],\"channels\":[\"ticker\"]}", ch)),
+	)
+}
+
+func (c *coinbaseWs) Subscribe(from, to string) (err error) {
+	c.subMu.Lock()
+	defer c.subMu.Unlock()
+	var ch = buildProductId(from, to)
+	if err = c.sendSubscribeMsg(ch); err != nil {
+		return
+	}
+	c.subscribes[ch] = domain.NewSubscribe(from, to, 0)
+	return
+}
+
+func (c *coinbaseWs) sendSubscribeMsg(ch string) error {
+	return c.conn.Write(c.ctx, websocket.MessageText, []byte(
+		fmt.Sprintf("{\"type\":\"subscribe\",\"product_ids\":[\"%s\"],\"channels\":[\"ticker\"]}", ch)),
+	)
+}
+
+func (c *coinbaseWs) ListSubscribes() domain.Subscribes {
+	s := make(domain.Subscribes, len(c.subscribes))
+	c.subMu.RLock()
+	defer c.subMu.RUnlock()
+	for k, v := range c.subscribes {
+		s[k] = v
+	}
+	return s
+}
+
+func convertCoinbaseWsDataToDomain(from, to string, d *coinbaseWsData) *domain.Data {
+	if d == nil {
+		return nil
+	}
+	var (
+		change    = d.Price - d.Open24h
+		changePct float64
+	)
+	if d.Open24h != 0 {
+		changePct = change / d.Open24h * 100
+	}
+	b, _ := json.Marshal(&domain.Raw{
+		FromSymbol:      from,
+		ToSymbol:        to,
+		Change24Hour:    change,
+		ChangePct24Hour: changePct,
+		Open24Hour:      d.Open24h,
+		Volume24Hour:    d.Volume24h,
+		Low24Hour:       d.Low24h,
+		High24Hour:      d.High24h,
+		Price:           d.Price,
+		LastUpdate:      d.Time.UnixMilli(),
+	})
+	return &domain.Data{
+		FromSymbol:      from,
+		ToSymbol:        to,
+		Change24Hour:    change,
+		ChangePct24Hour: changePct,
+		Open24Hour:      d.Open24h,
+		Volume24Hour:    d.Volume24h,
+		Low24Hour:       d.Low24h,
+		High24Hour:      d.High24h,
+		Price:           d.Price,
+		LastUpdate:      d.Time.UnixMilli(),
+		DisplayDataRaw:  string(b),
+		Provider:        Name,
+	}
+}
@@ -0,0 +1,156 @@
+package coinbase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/streamdp/ccd/domain"
+	"nhooyr.io/websocket"
+)
+
+type request struct {
+	Type       string   `json:"type"`
+	ProductIds []string `json:"product_ids"`
+	Channels   []string `json:"channels"`
+}
+
+// newTestWs connects the client to the stand-in server, the server replays the recorded messages after every
+// subscribe request and passes all the requests to the channel
+func newTestWs(t *testing.T) (*coinbaseWs, chan *domain.Data, chan request, *strings.Builder) {
+	recorded, err := os.ReadFile(filepath.Join("testdata", "ws.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	requests := make(chan request, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer func() {
+			_ = conn.Close(websocket.StatusNormalClosure, "")
+		}()
+		for {
+			_, b, err := conn.Read(r.Context())
+			if err != nil {
+				return
+			}
+			req := request{}
+			if err = json.Unmarshal(b, &req); err != nil {
+				t.Error(err)
+				return
+			}
+			requests <- req
+			if req.Type != "subscribe" {
+				continue
+			}
+			for _, msg := range bytes.Split(bytes.TrimSpace(recorded), []byte("\n")) {
+				if err = conn.Write(r.Context(), websocket.MessageText, msg); err != nil {
+					return
+				}
+			}
+		}
+	}))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(func() {
+		cancel()
+		srv.Close()
+	})
+	logs := &strings.Builder{}
+	c := &coinbaseWs{
+		ctx:        ctx,
+		l:          log.New(logs, "", 0),
+		wssUrl:     "ws" + strings.TrimPrefix(srv.URL, "http"),
+		subscribes: domain.Subscribes{},
+	}
+	if err = c.reconnect(); err != nil {
+		t.Fatal(err)
+	}
+	pipe := make(chan *domain.Data, 10)
+	c.handleWsMessages(pipe)
+	return c, pipe, requests, logs
+}
+
+func TestWsSubscribe(t *testing.T) {
+	c, pipe, requests, _ := newTestWs(t)
+	if err := c.Subscribe("BTC", "USD"); err != nil {
+		t.Fatal(err)
+	}
+	if req := <-requests; req.Type != "subscribe" || len(req.ProductIds) != 1 || req.ProductIds[0] != "BTC-USD" ||
+		len(req.Channels) != 1 || req.Channels[0] != "ticker" {
+		t.Errorf("got request %+v, want subscribe to BTC-USD ticker", req)
+	}
+	if _, ok := c.ListSubscribes()["BTC-USD"]; !ok {
+		t.Error("subscribe is not listed")
+	}
+	// both recorded ticks happened in the same second, they must stay distinct
+	want := []struct {
+		price      float64
+		lastUpdate int64
+	}{
+		{43183.33, 1705313045123},
+		{43183.5, 1705313045789},
+	}
+	for _, w := range want {
+		select {
+		case d := <-pipe:
+			if d.FromSymbol != "BTC" || d.ToSymbol != "USD" || d.Provider != Name || d.Price != w.price ||
+				d.Open24Hour != 42500.01 || d.High24Hour != 43500 || d.Low24Hour != 42100.5 ||
+				d.LastUpdate != w.lastUpdate {
+				t.Errorf("got unexpected data %+v, want price %v at %d", d, w.price, w.lastUpdate)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("no data received")
+		}
+	}
+
+	if err := c.Unsubscribe("BTC", "USD"); err != nil {
+		t.Fatal(err)
+	}
+	if req := <-requests; req.Type != "unsubscribe" || len(req.ProductIds) != 1 || req.ProductIds[0] != "BTC-USD" {
+		t.Errorf("got request %+v, want unsubscribe from BTC-USD", req)
+	}
+	if len(c.ListSubscribes()) != 0 {
+		t.Error("subscribe is still listed")
+	}
+}
+
+func TestConvertWsData(t *testing.T) {
+	recorded, err := os.ReadFile(filepath.Join("testdata", "ws.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tickers, errs int
+	for _, line := range bytes.Split(bytes.TrimSpace(recorded), []byte("\n")) {
+		data := &coinbaseWsData{}
+		if err = json.Unmarshal(line, data); err != nil {
+			t.Fatalf("failed to parse %s: %v", line, err)
+		}
+		switch data.Type {
+		case "ticker":
+			tickers++
+			d := convertCoinbaseWsDataToDomain("BTC", "USD", data)
+			if d.LastUpdate%1000 == 0 || d.Volume24Hour == 0 || d.Change24Hour != d.Price-d.Open24Hour {
+				t.Errorf("got unexpected data %+v", d)
+			}
+		case "error":
+			errs++
+			if data.Message == "" || data.Reason == "" {
+				t.Errorf("got empty error %+v", data)
+			}
+		}
+	}
+	if tickers != 2 || errs != 1 {
+		t.Errorf("got %d tickers and %d errors, want 2 and 1", tickers, errs)
+	}
+}
@@ -0,0 +1,109 @@
+package clients
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/streamdp/ccd/domain"
+)
+
+// madThreshold is the modified z-score above which the price is considered an outlier
+const madThreshold = 3.5
+
+// minTolerance is the relative deviation from the median that is never an outlier, it is used when most of the
+// prices are the same and the MAD is zero
+const minTolerance = 0.001
+
+// GetAll ask rest clients of all data providers concurrently, skipping ones with the open circuit breaker, and return
+// data of the providers that answered in the order of priority
+func (p *Providers) GetAll(from, to string) (result []*domain.Data) {
+	var (
+		wg   sync.WaitGroup
+		data = make([]*domain.Data, len(p.names))
+	)
+	for i, name := range p.names {
+		if !p.breakers[name].allow() {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			if d, err := p.get(name, from, to); err == nil {
+				data[i] = d
+			}
+		}(i, name)
+	}
+	wg.Wait()
+	for _, d := range data {
+		if d != nil {
+			result = append(result, d)
+		}
+	}
+	return
+}
+
+// NewConsensus calculate volume weighted price of the data providers after dropping outliers with the median
+// absolute deviation filter. The simple average is used when any of the remaining providers reported zero volume,
+// otherwise their prices would be silently ignored.
+// The weights are the 24h volumes in the base currency, the built-in providers report them so, the generic one
+// should be mapped to it.
+func NewConsensus(from, to string, data []*domain.Data) (*domain.Consensus, error) {
+	if len(data) == 0 {
+		return nil, errors.New("no data provider answered")
+	}
+	prices := make([]float64, len(data))
+	for i := range data {
+		prices[i] = data[i].Price
+	}
+	m := median(prices)
+	deviations := make([]float64, len(data))
+	for i := range prices {
+		deviations[i] = math.Abs(prices[i] - m)
+	}
+	mad := median(deviations)
+	c := &domain.Consensus{
+		FromSymbol: from,
+		ToSymbol:   to,
+	}
+	var sum, weightedSum, volume float64
+	var n int
+	equalWeights := false
+	for i, d := range data {
+		s := &domain.ConsensusSource{
+			Provider:   d.Provider,
+			Price:      d.Price,
+			Volume:     d.Volume24Hour,
+			LastUpdate: d.LastUpdate,
+		}
+		if mad == 0 {
+			s.Outlier = deviations[i] > minTolerance*math.Abs(m)
+		} else {
+			// 0.6745 makes the MAD a consistent estimator of the standard deviation for normally distributed data
+			s.Outlier = 0.6745*deviations[i]/mad > madThreshold
+		}
+		c.Sources = append(c.Sources, s)
+		if s.Outlier {
+			continue
+		}
+		n++
+		sum += s.Price
+		weightedSum += s.Price * s.Volume
+		volume += s.Volume
+		equalWeights = equalWeights || s.Volume <= 0
+	}
+	if c.Price = sum / float64(n); !equalWeights && volume > 0 {
+		c.Price = weightedSum / volume
+	}
+	return c, nil
+}
+
+func median(v []float64) float64 {
+	s := append([]float64(nil), v...)
+	sort.Float64s(s)
+	if l := len(s); l%2 == 0 {
+		return (s[l/2-1] + s[l/2]) / 2
+	}
+	return s[len(s)/2]
+}
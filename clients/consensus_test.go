@@ -0,0 +1,78 @@
+package clients
+
+import (
+	"math"
+	"testing"
+
+	"github.com/streamdp/ccd/domain"
+)
+
+func TestNewConsensus(t *testing.T) {
+	type source struct {
+		price, volume float64
+		outlier       bool
+	}
+	tests := []struct {
+		name    string
+		sources []source
+		want    float64
+		wantErr bool
+	}{
+		{
+			name:    "volume weighted",
+			sources: []source{{price: 100, volume: 1}, {price: 101, volume: 3}},
+			want:    100.75,
+		},
+		{
+			name: "outlier rejected",
+			sources: []source{
+				{price: 100, volume: 1}, {price: 101, volume: 1}, {price: 102, volume: 1},
+				{price: 150, volume: 100, outlier: true},
+			},
+			want: 101,
+		},
+		{
+			name: "zero mad tolerance",
+			sources: []source{
+				{price: 100, volume: 1}, {price: 100, volume: 1}, {price: 100, volume: 1},
+				{price: 100.05, volume: 2}, {price: 101, volume: 1, outlier: true},
+			},
+			want: 100.02,
+		},
+		{
+			name:    "equal weights without volume",
+			sources: []source{{price: 100, volume: 5}, {price: 102}, {price: 101, volume: 1}},
+			want:    101,
+		},
+		{
+			name:    "no data",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data []*domain.Data
+			for _, s := range tt.sources {
+				data = append(data, &domain.Data{Price: s.price, Volume24Hour: s.volume, Provider: "p"})
+			}
+			c, err := NewConsensus("BTC", "USD", data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if math.Abs(c.Price-tt.want) > 1e-9 {
+				t.Errorf("got price %v, want %v", c.Price, tt.want)
+			}
+			if len(c.Sources) != len(tt.sources) {
+				t.Fatalf("got %d sources, want %d", len(c.Sources), len(tt.sources))
+			}
+			for i, s := range tt.sources {
+				if c.Sources[i].Outlier != s.outlier {
+					t.Errorf("source %d: got outlier %v, want %v", i, c.Sources[i].Outlier, s.outlier)
+				}
+			}
+		})
+	}
+}
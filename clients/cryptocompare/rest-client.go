@@ -9,11 +9,15 @@ import (
 	"time"
 
 	"github.com/streamdp/ccd/clients"
+	"github.com/streamdp/ccd/clients/recorder"
 	"github.com/streamdp/ccd/config"
 	"github.com/streamdp/ccd/domain"
 )
 
 const (
+	// Name of the data provider
+	Name = "cryptocompare"
+
 	apiUrl = "https://min-api.cryptocompare.com"
 
 	// Multiple Symbols Full Data - Get all the current trading info (price, vol, open, high, low etc) of any list of
@@ -26,10 +30,11 @@ const (
 type cryptoCompareRest struct {
 	apiKey string
 	client *http.Client
+	rec    *recorder.Recorder
 }
 
 // Init apiKey, apiUrl, wsURL variables with environment values and return CryptoCompareData structure
-func Init() (rc clients.RestClient, err error) {
+func Init(rec *recorder.Recorder) (rc clients.RestClient, err error) {
 	var apiKey string
 	if apiKey, err = getApiKey(); err != nil {
 		return
@@ -39,6 +44,7 @@ func Init() (rc clients.RestClient, err error) {
 		client: &http.Client{
 			Timeout: time.Duration(config.HttpClientTimeout) * time.Millisecond,
 		},
+		rec: rec,
 	}, nil
 }
 
@@ -68,6 +74,7 @@ func (cc *cryptoCompareRest) Get(fSym string, tSym string) (ds *domain.Data, err
 	if body, err = io.ReadAll(response.Body); err != nil {
 		return
 	}
+	cc.rec.Record(Name, recorder.Rest, body)
 	if response.StatusCode != 200 {
 		return
 	}
@@ -107,6 +114,7 @@ func convertToDomain(from, to string, d *cryptoCompareData) *domain.Data {
 		MktCap:          r.MktCap,
 		LastUpdate:      r.LastUpdate,
 		DisplayDataRaw:  string(b),
+		Provider:        Name,
 	}
 }
 
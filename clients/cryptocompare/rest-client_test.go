@@ -0,0 +1,21 @@
+package cryptocompare
+
+import "testing"
+
+func TestConvertToDomain(t *testing.T) {
+	d := convertToDomain("BTC", "USD", &cryptoCompareData{Raw: map[string]map[string]*Response{
+		"BTC": {"USD": {Price: 42000, LastUpdate: 1705313045}},
+	}})
+	if d.Price != 42000 || d.LastUpdate != 1705313045 || d.Provider != Name {
+		t.Errorf("got unexpected data %+v", d)
+	}
+}
+
+func TestConvertWsData(t *testing.T) {
+	d := convertCryptoCompareWsDataToDomain(&cryptoCompareWsData{
+		FromSymbol: "BTC", ToSymbol: "USD", Price: 42000, LastUpdate: 1705313045,
+	})
+	if d.Price != 42000 || d.LastUpdate != 1705313045 || d.Provider != Name {
+		t.Errorf("got unexpected data %+v", d)
+	}
+}
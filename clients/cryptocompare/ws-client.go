@@ -15,6 +15,7 @@ import (
 	"nhooyr.io/websocket"
 
 	"github.com/streamdp/ccd/clients"
+	"github.com/streamdp/ccd/clients/recorder"
 )
 
 const wssUrl = "wss://streamer.cryptocompare.com/v2"
@@ -22,13 +23,14 @@ const wssUrl = "wss://streamer.cryptocompare.com/v2"
 type cryptoCompareWs struct {
 	ctx        context.Context
 	l          *log.Logger
+	rec        *recorder.Recorder
 	conn       *websocket.Conn
 	apiKey     string
 	subscribes domain.Subscribes
 	subMu      sync.RWMutex
 }
 
-func InitWs(pipe chan *domain.Data, l *log.Logger) (_ clients.WsClient, err error) {
+func InitWs(pipe chan *domain.Data, l *log.Logger, rec *recorder.Recorder) (_ clients.WsClient, err error) {
 	var apiKey string
 	if apiKey, err = getApiKey(); err != nil {
 		return nil, err
@@ -36,6 +38,7 @@ func InitWs(pipe chan *domain.Data, l *log.Logger) (_ clients.WsClient, err erro
 	h := &cryptoCompareWs{
 		ctx:        context.Background(),
 		l:          l,
+		rec:        rec,
 		apiKey:     apiKey,
 		subscribes: domain.Subscribes{},
 	}
@@ -142,6 +145,7 @@ func (c *cryptoCompareWs) handleWsMessages(pipe chan *domain.Data) {
 					}
 					continue
 				}
+				c.rec.Record(Name, recorder.Ws, body)
 				data := &cryptoCompareWsData{}
 				if err = json.Unmarshal(body, data); err != nil {
 					c.l.Println(err)
@@ -236,5 +240,6 @@ func convertCryptoCompareWsDataToDomain(d *cryptoCompareWsData) *domain.Data {
 		MktCap:         d.CurrentSupplyMktCap,
 		LastUpdate:     d.LastUpdate,
 		DisplayDataRaw: string(b),
+		Provider:       Name,
 	}
 }
@@ -0,0 +1,36 @@
+package clients
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/streamdp/ccd/domain"
+)
+
+// GetFirst ask rest clients of the data providers in the order of priority, skipping ones with the open circuit
+// breaker, and return data from the first provider that answered together with its name
+func (p *Providers) GetFirst(from, to string) (d *domain.Data, provider string, err error) {
+	var errs []error
+	for _, name := range p.names {
+		if !p.breakers[name].allow() {
+			errs = append(errs, fmt.Errorf("%s: circuit breaker is open", name))
+			continue
+		}
+		if d, err = p.get(name, from, to); err == nil {
+			return d, name, nil
+		}
+		errs = append(errs, err)
+	}
+	return nil, "", errors.Join(errs...)
+}
+
+func (p *Providers) get(name, from, to string) (d *domain.Data, err error) {
+	if d, err = p.rest[name].Get(from, to); err == nil && d == nil {
+		err = errors.New("empty response")
+	}
+	if err != nil {
+		err = fmt.Errorf("%s: %w", name, err)
+	}
+	p.breakers[name].report(err)
+	return
+}
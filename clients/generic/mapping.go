@@ -0,0 +1,116 @@
+package generic
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Mapping describes how to request a ticker api and how to map its response to the domain.Data, it could be written
+// in yaml or in json:
+//
+//	url: https://www.bitstamp.net/api/v2/ticker/{from}{to}/
+//	symbols:
+//	  case: lower
+//	  aliases:
+//	    USD: USDT
+//	fields:
+//	  price: $.last
+//	  high_24_hour: $.high
+//	  last_update: $.timestamp
+type Mapping struct {
+	// Url template with {from} and {to} placeholders
+	Url     string  `yaml:"url" json:"url"`
+	Symbols Symbols `yaml:"symbols" json:"symbols"`
+	// Fields paths to the values in the response keyed by the domain.Data json field names, paths could contain
+	// {from} and {to} placeholders too
+	Fields map[string]string `yaml:"fields" json:"fields"`
+}
+
+// Symbols rules to convert our currency symbols to the api ones
+type Symbols struct {
+	// Case "lower", "upper" or empty to keep symbols as is
+	Case string `yaml:"case" json:"case"`
+	// Aliases replace our symbols with the api ones, e.g. USD: USDT
+	Aliases map[string]string `yaml:"aliases" json:"aliases"`
+}
+
+var dataFields = map[string]struct{}{
+	"change_24_hour":     {},
+	"change_pct_24_hour": {},
+	"open_24_hour":       {},
+	"volume_24_hour":     {},
+	"low_24_hour":        {},
+	"high_24_hour":       {},
+	"price":              {},
+	"supply":             {},
+	"mkt_cap":            {},
+	"last_update":        {},
+}
+
+// LoadMapping read and validate the mapping file
+func LoadMapping(name string) (m *Mapping, err error) {
+	var b []byte
+	if b, err = os.ReadFile(name); err != nil {
+		return nil, err
+	}
+	m = &Mapping{}
+	// json is a subset of yaml, so the yaml decoder reads both
+	if err = yaml.Unmarshal(b, m); err != nil {
+		return nil, fmt.Errorf("failed to parse mapping file: %w", err)
+	}
+	return m, m.validate()
+}
+
+func (m *Mapping) validate() error {
+	if m.Url == "" {
+		return errors.New("mapping file: url is required")
+	}
+	if _, ok := m.Fields["price"]; !ok {
+		return errors.New("mapping file: fields.price is required")
+	}
+	for k := range m.Fields {
+		if _, ok := dataFields[k]; !ok {
+			return fmt.Errorf("mapping file: unknown field %q", k)
+		}
+	}
+	switch strings.ToLower(m.Symbols.Case) {
+	case "", "lower", "upper":
+	default:
+		return fmt.Errorf("mapping file: unknown symbols case %q", m.Symbols.Case)
+	}
+	return nil
+}
+
+// symbol convert our currency symbol to the api one
+func (m *Mapping) symbol(s string) string {
+	if a, ok := m.Symbols.Aliases[strings.ToUpper(s)]; ok {
+		s = a
+	}
+	switch strings.ToLower(m.Symbols.Case) {
+	case "lower":
+		return strings.ToLower(s)
+	case "upper":
+		return strings.ToUpper(s)
+	}
+	return s
+}
+
+// expand replace {from} and {to} placeholders with the api symbols
+func (m *Mapping) expand(s, from, to string) string {
+	return strings.NewReplacer("{from}", m.symbol(from), "{to}", m.symbol(to)).Replace(s)
+}
+
+// url of the pair ticker, the api symbols are escaped, so they can't change the rest of the url
+func (m *Mapping) url(from, to string) string {
+	return strings.NewReplacer("{from}", escape(m.symbol(from)), "{to}", escape(m.symbol(to))).Replace(m.Url)
+}
+
+// escape the symbol to fit both the path and the query of the url
+func escape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
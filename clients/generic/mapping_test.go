@@ -0,0 +1,61 @@
+package generic
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadMapping(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr bool
+	}{
+		{"yaml", "m.yaml", "url: https://example.com/{from}{to}\nfields:\n  price: $.last\n", false},
+		{"json", "m.json", `{"url":"https://example.com/{from}{to}","fields":{"price":"$.last"}}`, false},
+		{"no url", "m.yaml", "fields:\n  price: $.last\n", true},
+		{"no price", "m.yaml", "url: https://example.com\nfields:\n  high_24_hour: $.high\n", true},
+		{"unknown field", "m.yaml", "url: https://example.com\nfields:\n  price: $.last\n  bid: $.bid\n", true},
+		{"unknown case", "m.yaml", "url: https://example.com\nsymbols:\n  case: title\nfields:\n  price: $.last\n",
+			true},
+		{"broken", "m.json", `{"url":`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(name, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadMapping(name); (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+	if _, err := LoadMapping(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("missing mapping file loaded")
+	}
+}
+
+func TestMappingUrl(t *testing.T) {
+	m := &Mapping{
+		Url:     "https://example.com/ticker/{from}{to}/?pair={from}-{to}",
+		Symbols: Symbols{Case: "lower", Aliases: map[string]string{"USD": "USDT", "FOO": "a/b&c d"}},
+	}
+	tests := []struct {
+		name     string
+		from, to string
+		want     string
+	}{
+		{"alias and case", "BTC", "usd", "https://example.com/ticker/btcusdt/?pair=btc-usdt"},
+		{"escaped", "foo", "USD", "https://example.com/ticker/a%2Fb%26c%20dusdt/?pair=a%2Fb%26c%20d-usdt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.url(tt.from, tt.to); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
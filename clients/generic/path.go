@@ -0,0 +1,74 @@
+package generic
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// lookup the value in the decoded json by the JSONPath-like path, only the root ($), child (.name or ['name']) and
+// array index ([0]) selectors are supported, e.g. $.data[0].last or $['RAW']['BTC']['USD'].PRICE
+func lookup(v interface{}, path string) (interface{}, error) {
+	tokens, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tokens {
+		switch node := v.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if v, ok = node[t]; !ok {
+				return nil, fmt.Errorf("%s: key %q not found", path, t)
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(t)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, fmt.Errorf("%s: index %q out of range", path, t)
+			}
+			v = node[i]
+		default:
+			return nil, fmt.Errorf("%s: can't select %q from a scalar value", path, t)
+		}
+	}
+	return v, nil
+}
+
+func parsePath(path string) (tokens []string, err error) {
+	p := strings.TrimPrefix(strings.TrimSpace(path), "$")
+	for len(p) > 0 {
+		switch p[0] {
+		case '.':
+			p = p[1:]
+			end := strings.IndexAny(p, ".[")
+			if end < 0 {
+				end = len(p)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("%s: empty key", path)
+			}
+			tokens = append(tokens, p[:end])
+			p = p[end:]
+		case '[':
+			end := strings.IndexByte(p, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("%s: unclosed bracket", path)
+			}
+			tokens = append(tokens, strings.Trim(p[1:end], `'"`))
+			p = p[end+1:]
+		default:
+			return nil, fmt.Errorf("%s: unexpected %q", path, p[0])
+		}
+	}
+	return tokens, nil
+}
+
+// toFloat convert json number or numeric string to float64
+func toFloat(v interface{}) (float64, error) {
+	switch n := v.(type) {
+	case float64:
+		return n, nil
+	case string:
+		return strconv.ParseFloat(n, 64)
+	}
+	return 0, fmt.Errorf("value %v is not a number", v)
+}
@@ -0,0 +1,68 @@
+package generic
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		want    []string
+		wantErr bool
+	}{
+		{"root", "$", nil, false},
+		{"child", "$.data.last", []string{"data", "last"}, false},
+		{"index", "$.data[0].last", []string{"data", "0", "last"}, false},
+		{"quoted", `$['RAW']["BTC"].PRICE`, []string{"RAW", "BTC", "PRICE"}, false},
+		{"without root", ".last", []string{"last"}, false},
+		{"empty key", "$.data..last", nil, true},
+		{"unclosed bracket", "$.data[0", nil, true},
+		{"unexpected", "$data", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePath(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	var v interface{}
+	body := `{"data":[{"last":"42000.5"}],"RAW":{"BTC":{"USD":{"PRICE":42000}}}}`
+	if err := json.Unmarshal([]byte(body), &v); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		path    string
+		want    interface{}
+		wantErr bool
+	}{
+		{"array", "$.data[0].last", "42000.5", false},
+		{"nested", "$['RAW']['BTC']['USD'].PRICE", float64(42000), false},
+		{"missing key", "$.data[0].high", nil, true},
+		{"index out of range", "$.data[1].last", nil, true},
+		{"not an index", "$.data[a]", nil, true},
+		{"scalar", "$.data[0].last.value", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := lookup(v, tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package generic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/streamdp/ccd/clients"
+	"github.com/streamdp/ccd/clients/recorder"
+	"github.com/streamdp/ccd/config"
+	"github.com/streamdp/ccd/domain"
+)
+
+// Name of the data provider
+const Name = "generic"
+
+type genericRest struct {
+	m      *Mapping
+	client *http.Client
+	rec    *recorder.Recorder
+}
+
+// Init load the mapping file from the "CCDC_GENERICCONFIG" environment variable (or -generic flag)
+func Init(rec *recorder.Recorder) (clients.RestClient, error) {
+	if config.GenericConfig == "" {
+		return nil, errors.New("you should specify the mapping file with -generic flag or \"CCDC_GENERICCONFIG\"" +
+			" in you OS environment")
+	}
+	m, err := LoadMapping(config.GenericConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &genericRest{
+		m: m,
+		client: &http.Client{
+			Timeout: time.Duration(config.HttpClientTimeout) * time.Millisecond,
+		},
+		rec: rec,
+	}, nil
+}
+
+func (g *genericRest) Get(fSym string, tSym string) (ds *domain.Data, err error) {
+	var (
+		response *http.Response
+		body     []byte
+		rawData  interface{}
+	)
+	if response, err = g.client.Get(g.m.url(fSym, tSym)); err != nil {
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(response.Body)
+	if body, err = io.ReadAll(response.Body); err != nil {
+		return nil, err
+	}
+	g.rec.Record(Name, recorder.Rest, body)
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", response.StatusCode)
+	}
+	if err = json.Unmarshal(body, &rawData); err != nil {
+		return nil, err
+	}
+	return g.convertToDomain(fSym, tSym, rawData)
+}
+
+func (g *genericRest) convertToDomain(from, to string, rawData interface{}) (*domain.Data, error) {
+	values := make(map[string]float64, len(g.m.Fields))
+	for field, path := range g.m.Fields {
+		v, err := lookup(rawData, g.m.expand(path, from, to))
+		if err != nil {
+			return nil, err
+		}
+		if values[field], err = toFloat(v); err != nil {
+			return nil, fmt.Errorf("%s: %w", field, err)
+		}
+	}
+	lastUpdate := toMillis(values["last_update"])
+	if lastUpdate == 0 {
+		lastUpdate = time.Now().UnixMilli()
+	}
+	b, _ := json.Marshal(&domain.Raw{
+		FromSymbol:      from,
+		ToSymbol:        to,
+		Change24Hour:    values["change_24_hour"],
+		ChangePct24Hour: values["change_pct_24_hour"],
+		Open24Hour:      values["open_24_hour"],
+		Volume24Hour:    values["volume_24_hour"],
+		Low24Hour:       values["low_24_hour"],
+		High24Hour:      values["high_24_hour"],
+		Price:           values["price"],
+		Supply:          values["supply"],
+		MktCap:          values["mkt_cap"],
+		LastUpdate:      lastUpdate,
+	})
+	return &domain.Data{
+		FromSymbol:      from,
+		ToSymbol:        to,
+		Change24Hour:    values["change_24_hour"],
+		ChangePct24Hour: values["change_pct_24_hour"],
+		Open24Hour:      values["open_24_hour"],
+		Volume24Hour:    values["volume_24_hour"],
+		Low24Hour:       values["low_24_hour"],
+		High24Hour:      values["high_24_hour"],
+		Price:           values["price"],
+		Supply:          values["supply"],
+		MktCap:          values["mkt_cap"],
+		LastUpdate:      lastUpdate,
+		DisplayDataRaw:  string(b),
+		Provider:        Name,
+	}, nil
+}
+
+// toMillis converts the unix time in seconds (with the fraction), milliseconds, microseconds or nanoseconds to
+// milliseconds, the unit is guessed by the magnitude
+func toMillis(t float64) int64 {
+	switch {
+	case t > 1e17:
+		return int64(math.Round(t / 1e6))
+	case t > 1e14:
+		return int64(math.Round(t / 1e3))
+	case t > 9999999999:
+		return int64(math.Round(t))
+	}
+	return int64(math.Round(t * 1e3))
+}
@@ -0,0 +1,25 @@
+package generic
+
+import "testing"
+
+func TestToMillis(t *testing.T) {
+	tests := []struct {
+		name string
+		t    float64
+		want int64
+	}{
+		{"seconds", 1705313045, 1705313045000},
+		{"seconds with fraction", 1705313045.1234, 1705313045123},
+		{"milliseconds", 1705313045123, 1705313045123},
+		{"microseconds", 1705313045123456, 1705313045123},
+		{"nanoseconds", 1705313045123456789, 1705313045123},
+		{"not set", 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := toMillis(tt.t); got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
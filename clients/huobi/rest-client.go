@@ -10,11 +10,15 @@ import (
 	"time"
 
 	"github.com/streamdp/ccd/clients"
+	"github.com/streamdp/ccd/clients/recorder"
 	"github.com/streamdp/ccd/config"
 	"github.com/streamdp/ccd/domain"
 )
 
 const (
+	// Name of the data provider
+	Name = "huobi"
+
 	apiUrl = "https://api.huobi.pro"
 
 	// Get Latest Aggregated Ticker https://huobiapi.github.io/docs/spot/v1/en/#get-latest-aggregated-ticker
@@ -25,13 +29,15 @@ const (
 
 type huobiRest struct {
 	client *http.Client
+	rec    *recorder.Recorder
 }
 
-func Init() (clients.RestClient, error) {
+func Init(rec *recorder.Recorder) (clients.RestClient, error) {
 	return &huobiRest{
 		client: &http.Client{
 			Timeout: time.Duration(config.HttpClientTimeout) * time.Millisecond,
 		},
+		rec: rec,
 	}, nil
 }
 
@@ -54,6 +60,7 @@ func (h *huobiRest) Get(fSym string, tSym string) (ds *domain.Data, err error) {
 	if body, err = io.ReadAll(response.Body); err != nil {
 		return nil, err
 	}
+	h.rec.Record(Name, recorder.Rest, body)
 	if response.StatusCode != 200 {
 		return nil, err
 	}
@@ -97,6 +104,7 @@ func convertHuobiRestDataToDomain(from, to string, d *huobiRestData) *domain.Dat
 		Supply:         float64(d.Tick.Count),
 		LastUpdate:     d.Ts,
 		DisplayDataRaw: string(b),
+		Provider:       Name,
 	}
 }
 
@@ -17,6 +17,7 @@ import (
 	"nhooyr.io/websocket"
 
 	"github.com/streamdp/ccd/clients"
+	"github.com/streamdp/ccd/clients/recorder"
 )
 
 const wssUrl = "wss://api.huobi.pro/ws"
@@ -24,15 +25,17 @@ const wssUrl = "wss://api.huobi.pro/ws"
 type huobiWs struct {
 	ctx        context.Context
 	l          *log.Logger
+	rec        *recorder.Recorder
 	conn       *websocket.Conn
 	subscribes domain.Subscribes
 	subMu      sync.RWMutex
 }
 
-func InitWs(pipe chan *domain.Data, l *log.Logger) (clients.WsClient, error) {
+func InitWs(pipe chan *domain.Data, l *log.Logger, rec *recorder.Recorder) (clients.WsClient, error) {
 	h := &huobiWs{
 		ctx:        context.Background(),
 		l:          l,
+		rec:        rec,
 		subscribes: domain.Subscribes{},
 	}
 	if err := h.reconnect(); err != nil {
@@ -113,6 +116,7 @@ func (h *huobiWs) handleWsMessages(pipe chan *domain.Data) {
 					h.l.Println(err)
 					continue
 				}
+				h.rec.Record(Name, recorder.Ws, body)
 				if bytes.Contains(body, []byte("ping")) {
 					if err = h.pingHandler(body); err != nil {
 						if err = h.handleWsError(err); err != nil {
@@ -243,5 +247,6 @@ func convertHuobiWsDataToDomain(from, to string, d *huobiWsData) *domain.Data {
 		Supply:         float64(d.Tick.Count),
 		LastUpdate:     d.Ts,
 		DisplayDataRaw: string(b),
+		Provider:       Name,
 	}
 }
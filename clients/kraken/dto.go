@@ -0,0 +1,56 @@
+package kraken
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type krakenRestData struct {
+	Error  []string                     `json:"error"`
+	Result map[string]*krakenRestTicker `json:"result"`
+}
+
+// krakenRestTicker the arrays contain [today, last 24 hours] values, except the last trade (c) which is
+// [price, lot volume], the ask (a) and the bid (b) which are [price, whole lot volume, lot volume]
+type krakenRestTicker struct {
+	Ask    []string `json:"a"`
+	Bid    []string `json:"b"`
+	Last   []string `json:"c"`
+	Volume []string `json:"v"`
+	Vwap   []string `json:"p"`
+	Trades []int64  `json:"t"`
+	Low    []string `json:"l"`
+	High   []string `json:"h"`
+	Open   string   `json:"o"`
+}
+
+// krakenRestTrades the result contains the trades of the pair and the "last" id used for the pagination
+type krakenRestTrades struct {
+	Error  []string                   `json:"error"`
+	Result map[string]json.RawMessage `json:"result"`
+}
+
+type krakenWsData struct {
+	Channel string            `json:"channel"`
+	Type    string            `json:"type"`
+	Data    []*krakenWsTicker `json:"data"`
+	Method  string            `json:"method"`
+	Success bool              `json:"success"`
+	Error   string            `json:"error"`
+}
+
+type krakenWsTicker struct {
+	Symbol    string    `json:"symbol"`
+	Bid       float64   `json:"bid"`
+	BidQty    float64   `json:"bid_qty"`
+	Ask       float64   `json:"ask"`
+	AskQty    float64   `json:"ask_qty"`
+	Last      float64   `json:"last"`
+	Volume    float64   `json:"volume"`
+	Vwap      float64   `json:"vwap"`
+	Low       float64   `json:"low"`
+	High      float64   `json:"high"`
+	Change    float64   `json:"change"`
+	ChangePct float64   `json:"change_pct"`
+	Timestamp time.Time `json:"timestamp"`
+}
@@ -0,0 +1,190 @@
+package kraken
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/streamdp/ccd/clients"
+	"github.com/streamdp/ccd/clients/recorder"
+	"github.com/streamdp/ccd/config"
+	"github.com/streamdp/ccd/domain"
+)
+
+const (
+	// Name of the data provider
+	Name = "kraken"
+
+	apiUrl = "https://api.kraken.com"
+
+	// Get Ticker Information https://docs.kraken.com/rest/#tag/Market-Data/operation/getTickerInformation
+	// Today's prices start at midnight UTC.
+	// Request Parameters "pair" (asset pair to get data for, e.g. XBTUSD)
+	tickerInformation = "/0/public/Ticker"
+
+	// Get Recent Trades https://docs.kraken.com/rest/#tag/Market-Data/operation/getRecentTrades
+	// Request Parameters "pair" (asset pair to get data for) and "count" (number of the last trades to return)
+	recentTrades = "/0/public/Trades"
+)
+
+type krakenRest struct {
+	apiUrl string
+	client *http.Client
+	rec    *recorder.Recorder
+}
+
+func Init(rec *recorder.Recorder) (clients.RestClient, error) {
+	return &krakenRest{
+		apiUrl: apiUrl,
+		client: &http.Client{
+			Timeout: time.Duration(config.HttpClientTimeout) * time.Millisecond,
+		},
+		rec: rec,
+	}, nil
+}
+
+func (k *krakenRest) Get(fSym string, tSym string) (ds *domain.Data, err error) {
+	var (
+		tickers = &krakenRestData{}
+		trades  = &krakenRestTrades{}
+		t       *krakenRestTicker
+		updated int64
+	)
+	if err = k.get(tickerInformation, fSym, tSym, tickers); err != nil {
+		return nil, err
+	}
+	if len(tickers.Error) > 0 {
+		return nil, errors.New(strings.Join(tickers.Error, ", "))
+	}
+	// the ticker has no time, the time of the last trade is used instead
+	if err = k.get(recentTrades, fSym, tSym, trades); err != nil {
+		return nil, err
+	}
+	if len(trades.Error) > 0 {
+		return nil, errors.New(strings.Join(trades.Error, ", "))
+	}
+	for _, name := range restPairNames(fSym, tSym) {
+		if t == nil {
+			t = tickers.Result[name]
+		}
+		if updated == 0 {
+			updated = lastTradeTime(trades.Result[name])
+		}
+	}
+	if t == nil || updated == 0 {
+		return nil, fmt.Errorf("no ticker for %s/%s in the response", fSym, tSym)
+	}
+	return convertKrakenRestDataToDomain(fSym, tSym, t, updated), nil
+}
+
+func (k *krakenRest) get(path, fSym, tSym string, v interface{}) (err error) {
+	var (
+		u        *url.URL
+		response *http.Response
+		body     []byte
+	)
+	if u, err = k.buildURL(path, fSym, tSym); err != nil {
+		return err
+	}
+	if response, err = k.client.Get(u.String()); err != nil {
+		return err
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(response.Body)
+	if body, err = io.ReadAll(response.Body); err != nil {
+		return err
+	}
+	k.rec.Record(Name, recorder.Rest, body)
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", response.StatusCode)
+	}
+	return json.Unmarshal(body, v)
+}
+
+// lastTradeTime return the time of the last trade in milliseconds, the trade is [price, volume, time, ...] where the
+// time is the unix time in seconds with the fractional part
+func lastTradeTime(raw json.RawMessage) int64 {
+	var trades [][]interface{}
+	if err := json.Unmarshal(raw, &trades); err != nil || len(trades) == 0 {
+		return 0
+	}
+	last := trades[len(trades)-1]
+	if len(last) < 3 {
+		return 0
+	}
+	if t, ok := last[2].(float64); ok {
+		return int64(math.Round(t * 1000))
+	}
+	return 0
+}
+
+// convertKrakenRestDataToDomain kraken has no 24h open price, so the change is calculated from today's open price
+func convertKrakenRestDataToDomain(from, to string, t *krakenRestTicker, lastUpdate int64) *domain.Data {
+	if t == nil {
+		return nil
+	}
+	var (
+		price     = parseFloat(t.Last, 0)
+		open      = parseFloat([]string{t.Open}, 0)
+		change    = price - open
+		changePct float64
+	)
+	if open != 0 {
+		changePct = change / open * 100
+	}
+	b, _ := json.Marshal(&domain.Raw{
+		FromSymbol:      from,
+		ToSymbol:        to,
+		Change24Hour:    change,
+		ChangePct24Hour: changePct,
+		Open24Hour:      open,
+		Volume24Hour:    parseFloat(t.Volume, 1),
+		Low24Hour:       parseFloat(t.Low, 1),
+		High24Hour:      parseFloat(t.High, 1),
+		Price:           price,
+		LastUpdate:      lastUpdate,
+	})
+	return &domain.Data{
+		FromSymbol:      from,
+		ToSymbol:        to,
+		Change24Hour:    change,
+		ChangePct24Hour: changePct,
+		Open24Hour:      open,
+		Volume24Hour:    parseFloat(t.Volume, 1),
+		Low24Hour:       parseFloat(t.Low, 1),
+		High24Hour:      parseFloat(t.High, 1),
+		Price:           price,
+		LastUpdate:      lastUpdate,
+		DisplayDataRaw:  string(b),
+		Provider:        Name,
+	}
+}
+
+func parseFloat(s []string, i int) float64 {
+	if i >= len(s) {
+		return 0
+	}
+	f, _ := strconv.ParseFloat(s[i], 64)
+	return f
+}
+
+func (k *krakenRest) buildURL(path, fSym, tSym string) (u *url.URL, err error) {
+	if u, err = url.Parse(k.apiUrl + path); err != nil {
+		return nil, err
+	}
+	query := u.Query()
+	query.Set("pair", toKraken(fSym)+toKraken(tSym))
+	if path == recentTrades {
+		query.Set("count", "1")
+	}
+	u.RawQuery = query.Encode()
+	return u, nil
+}
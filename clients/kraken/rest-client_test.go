@@ -0,0 +1,69 @@
+package kraken
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const (
+	restTicker = `{"error":[],"result":{"XXBTZUSD":{"a":["43183.40000","1","1.000"],"b":["43183.30000","2","2.000"],` +
+		`"c":["43183.40000","0.00120000"],"v":["1234.56789012","2345.67890123"],"p":["43000.1","42900.2"],` +
+		`"t":[12345,23456],"l":["42100.50000","42000.10000"],"h":["43500.00000","43600.00000"],"o":"42500.00000"}}}`
+	restTrades = `{"error":[],"result":{"XXBTZUSD":[["43183.40000","0.00120000",1705313045.1234,"b","l","",` +
+		`66366911]],"last":"1705313045123456789"}}`
+)
+
+func newTestRest(t *testing.T, status int, bodies map[string]string) *krakenRest {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("pair") != "XBTUSD" {
+			t.Errorf("got request %s, want pair XBTUSD", r.URL)
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(bodies[r.URL.Path]))
+	}))
+	t.Cleanup(srv.Close)
+	return &krakenRest{apiUrl: srv.URL, client: srv.Client()}
+}
+
+func TestRestGet(t *testing.T) {
+	k := newTestRest(t, http.StatusOK, map[string]string{tickerInformation: restTicker, recentTrades: restTrades})
+	d, err := k.Get("BTC", "USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.FromSymbol != "BTC" || d.ToSymbol != "USD" || d.Provider != Name {
+		t.Errorf("got pair %s:%s of %s, want BTC:USD of %s", d.FromSymbol, d.ToSymbol, d.Provider, Name)
+	}
+	if d.Price != 43183.4 || d.Open24Hour != 42500 || d.High24Hour != 43600 || d.Low24Hour != 42000.1 ||
+		d.Volume24Hour != 2345.67890123 {
+		t.Errorf("got unexpected data %+v", d)
+	}
+	// the time of the last trade in milliseconds
+	if d.LastUpdate != 1705313045123 {
+		t.Errorf("got last update %d, want 1705313045123", d.LastUpdate)
+	}
+}
+
+func TestRestGetError(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		bodies map[string]string
+		want   string
+	}{
+		{"kraken error", http.StatusOK, map[string]string{tickerInformation: `{"error":["EQuery:Unknown asset pair"]}`},
+			"EQuery:Unknown asset pair"},
+		{"status code", http.StatusBadGateway, map[string]string{}, "unexpected status code 502"},
+		{"no trades", http.StatusOK, map[string]string{tickerInformation: restTicker,
+			recentTrades: `{"error":[],"result":{"last":"0"}}`}, "no ticker for BTC/USD in the response"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := newTestRest(t, tt.status, tt.bodies)
+			if _, err := k.Get("BTC", "USD"); err == nil || err.Error() != tt.want {
+				t.Errorf("got error %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
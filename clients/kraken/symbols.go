@@ -0,0 +1,91 @@
+package kraken
+
+import (
+	"strings"
+)
+
+// assets translates our currency symbols to the kraken asset names where they differ
+var assets = map[string]string{
+	"BTC":  "XBT",
+	"DOGE": "XDG",
+}
+
+// legacyAssets translates kraken asset names to the legacy (X - crypto, Z - fiat) asset codes which are used in the
+// rest api pair names, e.g. XBT/USD is XXBTZUSD
+var legacyAssets = map[string]string{
+	"XBT": "XXBT",
+	"XDG": "XXDG",
+	"ETH": "XETH",
+	"ETC": "XETC",
+	"LTC": "XLTC",
+	"XRP": "XXRP",
+	"XLM": "XXLM",
+	"XMR": "XXMR",
+	"ZEC": "XZEC",
+	"USD": "ZUSD",
+	"EUR": "ZEUR",
+	"GBP": "ZGBP",
+	"JPY": "ZJPY",
+	"CAD": "ZCAD",
+	"AUD": "ZAUD",
+}
+
+var (
+	assetsReverse       = reverse(assets)
+	legacyAssetsReverse = reverse(legacyAssets)
+)
+
+func reverse(m map[string]string) map[string]string {
+	r := make(map[string]string, len(m))
+	for k, v := range m {
+		r[v] = k
+	}
+	return r
+}
+
+// toKraken return kraken asset name for our currency symbol
+func toKraken(s string) string {
+	s = strings.ToUpper(s)
+	if a, ok := assets[s]; ok {
+		return a
+	}
+	return s
+}
+
+// fromKraken return our currency symbol for the kraken asset name or legacy asset code
+func fromKraken(s string) string {
+	s = strings.ToUpper(s)
+	if a, ok := legacyAssetsReverse[s]; ok {
+		s = a
+	}
+	if a, ok := assetsReverse[s]; ok {
+		return a
+	}
+	return s
+}
+
+// restPairNames return all the names kraken could use for the currencies pair in the rest api response,
+// e.g. XBTUSD and XXBTZUSD for BTC/USD
+func restPairNames(from, to string) []string {
+	from, to = toKraken(from), toKraken(to)
+	names := []string{from + to}
+	lf, okFrom := legacyAssets[from]
+	lt, okTo := legacyAssets[to]
+	if okFrom && okTo {
+		names = append(names, lf+lt)
+	}
+	return names
+}
+
+// wsSymbol return ws v2 symbol name for the currencies pair, e.g. BTC/USD
+func wsSymbol(from, to string) string {
+	return strings.ToUpper(from + "/" + to)
+}
+
+// pairFromWsSymbol return our currencies pair for the ws v2 symbol name
+func pairFromWsSymbol(s string) (from, to string) {
+	if pair := strings.Split(s, "/"); len(pair) == 2 {
+		return fromKraken(pair[0]), fromKraken(pair[1])
+	}
+	return
+}
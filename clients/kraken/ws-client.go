@@ -0,0 +1,230 @@
+package kraken
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/streamdp/ccd/domain"
+	"nhooyr.io/websocket"
+
+	"github.com/streamdp/ccd/clients"
+	"github.com/streamdp/ccd/clients/recorder"
+)
+
+const wssUrl = "wss://ws.kraken.com/v2"
+
+type krakenWs struct {
+	ctx        context.Context
+	l          *log.Logger
+	rec        *recorder.Recorder
+	conn       *websocket.Conn
+	wssUrl     string
+	subscribes domain.Subscribes
+	subMu      sync.RWMutex
+}
+
+func InitWs(pipe chan *domain.Data, l *log.Logger, rec *recorder.Recorder) (clients.WsClient, error) {
+	k := &krakenWs{
+		ctx:        context.Background(),
+		l:          l,
+		rec:        rec,
+		wssUrl:     wssUrl,
+		subscribes: domain.Subscribes{},
+	}
+	if err := k.reconnect(); err != nil {
+		return nil, err
+	}
+	k.handleWsMessages(pipe)
+	return k, nil
+}
+
+func (k *krakenWs) reconnect() (err error) {
+	if k.conn != nil {
+		if err := k.conn.Close(websocket.StatusNormalClosure, ""); err != nil {
+			k.l.Println(err)
+			// reducing logs and CPU load when the server is unavailable
+			time.Sleep(10 * time.Second)
+		}
+	}
+	k.conn, _, err = websocket.Dial(k.ctx, k.wssUrl, nil)
+	return
+}
+
+func (k *krakenWs) resubscribe() (err error) {
+	k.subMu.RLock()
+	defer k.subMu.RUnlock()
+	for ch, v := range k.subscribes {
+		if err = k.sendSubscribeMsg(ch, v.Id()); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (k *krakenWs) handleWsError(err error) error {
+	k.l.Println(err)
+	for {
+		select {
+		case <-time.After(time.Minute):
+			return errors.New("reconnect failed")
+		default:
+			if err = k.reconnect(); err != nil {
+				time.Sleep(time.Second)
+				continue
+			}
+			if err = k.resubscribe(); err != nil {
+				time.Sleep(time.Second)
+				continue
+			}
+			return nil
+		}
+	}
+}
+
+func (k *krakenWs) handleWsMessages(pipe chan *domain.Data) {
+	go func() {
+		defer func(conn *websocket.Conn, code websocket.StatusCode, reason string) {
+			if err := conn.Close(code, reason); err != nil {
+				k.l.Println(err)
+			}
+		}(k.conn, websocket.StatusNormalClosure, "")
+		for {
+			select {
+			case <-k.ctx.Done():
+				return
+			default:
+				var (
+					body []byte
+					err  error
+				)
+				if _, body, err = k.conn.Read(k.ctx); err != nil {
+					if err = k.handleWsError(err); err != nil {
+						k.l.Println(err)
+						return
+					}
+					continue
+				}
+				k.rec.Record(Name, recorder.Ws, body)
+				data := &krakenWsData{}
+				if err = json.Unmarshal(body, data); err != nil {
+					k.l.Println(err)
+					continue
+				}
+				if data.Method != "" && !data.Success {
+					k.l.Printf("kraken %s request failed: %s", data.Method, data.Error)
+					continue
+				}
+				// skip heartbeats, status messages and method responses
+				if data.Channel != "ticker" {
+					continue
+				}
+				for _, t := range data.Data {
+					from, to := k.pairFromChannelName(t.Symbol)
+					if from != "" && to != "" {
+						pipe <- convertKrakenWsDataToDomain(from, to, t)
+					}
+				}
+			}
+		}
+	}()
+}
+
+func (k *krakenWs) pairFromChannelName(ch string) (from, to string) {
+	k.subMu.RLock()
+	defer k.subMu.RUnlock()
+	if c, ok := k.subscribes[ch]; ok {
+		return c.From, c.To
+	}
+	return pairFromWsSymbol(ch)
+}
+
+func (k *krakenWs) Unsubscribe(from, to string) (err error) {
+	k.subMu.Lock()
+	defer k.subMu.Unlock()
+	var ch = wsSymbol(from, to)
+	if c, ok := k.subscribes[ch]; ok {
+		if err = k.sendUnsubscribeMsg(ch, c.Id()); err != nil {
+			return
+		}
+		delete(k.subscribes, ch)
+	}
+	return
+}
+
+func (k *krakenWs) sendUnsubscribeMsg(ch string, id int64) error {
+	return k.conn.Write(k.ctx, websocket.MessageText, []byte(fmt.Sprintf(
+		"{\"method\":\"unsubscribe\",\"params\":{\"channel\":\"ticker\",\"symbol\":[\"%s\"]},\"req_id\":%d}", ch, id,
+	)))
+}
+
+func (k *krakenWs) Subscribe(from, to string) (err error) {
+	k.subMu.Lock()
+	defer k.subMu.Unlock()
+	var (
+		id = time.Now().UnixMilli()
+		ch = wsSymbol(from, to)
+	)
+	if err = k.sendSubscribeMsg(ch, id); err != nil {
+		return
+	}
+	k.subscribes[ch] = domain.NewSubscribe(from, to, id)
+	return
+}
+
+func (k *krakenWs) sendSubscribeMsg(ch string, id int64) error {
+	return k.conn.Write(k.ctx, websocket.MessageText, []byte(fmt.Sprintf(
+		"{\"method\":\"subscribe\",\"params\":{\"channel\":\"ticker\",\"symbol\":[\"%s\"]},\"req_id\":%d}", ch, id,
+	)))
+}
+
+func (k *krakenWs) ListSubscribes() domain.Subscribes {
+	s := make(domain.Subscribes, len(k.subscribes))
+	k.subMu.RLock()
+	defer k.subMu.RUnlock()
+	for key, v := range k.subscribes {
+		s[key] = v
+	}
+	return s
+}
+
+func convertKrakenWsDataToDomain(from, to string, t *krakenWsTicker) *domain.Data {
+	if t == nil {
+		return nil
+	}
+	lastUpdate := t.Timestamp.UnixMilli()
+	if t.Timestamp.IsZero() {
+		// the feed didn't send the ticker time, the receive time is the closest one
+		lastUpdate = time.Now().UnixMilli()
+	}
+	b, _ := json.Marshal(&domain.Raw{
+		FromSymbol:      from,
+		ToSymbol:        to,
+		Change24Hour:    t.Change,
+		ChangePct24Hour: t.ChangePct,
+		Open24Hour:      t.Last - t.Change,
+		Volume24Hour:    t.Volume,
+		Low24Hour:       t.Low,
+		High24Hour:      t.High,
+		Price:           t.Last,
+		LastUpdate:      lastUpdate,
+	})
+	return &domain.Data{
+		FromSymbol:      from,
+		ToSymbol:        to,
+		Change24Hour:    t.Change,
+		ChangePct24Hour: t.ChangePct,
+		Open24Hour:      t.Last - t.Change,
+		Volume24Hour:    t.Volume,
+		Low24Hour:       t.Low,
+		High24Hour:      t.High,
+		Price:           t.Last,
+		LastUpdate:      lastUpdate,
+		DisplayDataRaw:  string(b),
+		Provider:        Name,
+	}
+}
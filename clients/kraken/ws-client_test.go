@@ -0,0 +1,27 @@
+package kraken
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const wsTicker = `{"channel":"ticker","type":"update","data":[{"symbol":"BTC/USD","bid":43183.3,"bid_qty":2.0,` +
+	`"ask":43183.4,"ask_qty":1.0,"last":43183.4,"volume":2345.67890123,"vwap":42900.2,"low":42000.1,` +
+	`"high":43600.0,"change":683.4,"change_pct":1.61,"timestamp":"2024-01-15T10:04:05.123456Z"}]}`
+
+func TestConvertWsData(t *testing.T) {
+	data := &krakenWsData{}
+	if err := json.Unmarshal([]byte(wsTicker), data); err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Data) != 1 {
+		t.Fatalf("got %d tickers, want 1", len(data.Data))
+	}
+	d := convertKrakenWsDataToDomain("BTC", "USD", data.Data[0])
+	if d.Price != 43183.4 || d.Open24Hour != 43183.4-683.4 || d.Volume24Hour != 2345.67890123 || d.Provider != Name {
+		t.Errorf("got unexpected data %+v", d)
+	}
+	if d.LastUpdate != 1705313045123 {
+		t.Errorf("got last update %d, want the ticker time 1705313045123", d.LastUpdate)
+	}
+}
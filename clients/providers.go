@@ -0,0 +1,125 @@
+package clients
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/streamdp/ccd/clients/recorder"
+	"github.com/streamdp/ccd/domain"
+)
+
+// RestInit builds the rest client of the data provider, it should record the raw payloads with the recorder
+type RestInit func(rec *recorder.Recorder) (RestClient, error)
+
+// WsInit builds the ws client of the data provider, it should send received data to the pipe and record the raw
+// payloads with the recorder
+type WsInit func(pipe chan *domain.Data, l *log.Logger, rec *recorder.Recorder) (WsClient, error)
+
+// Registry keeps constructors of the known data providers
+type Registry struct {
+	rest map[string]RestInit
+	ws   map[string]WsInit
+	rec  *recorder.Recorder
+}
+
+// NewRegistry init empty data providers registry, the built clients record the raw payloads with the recorder, nil
+// recorder records nothing
+func NewRegistry(rec *recorder.Recorder) *Registry {
+	return &Registry{
+		rest: map[string]RestInit{},
+		ws:   map[string]WsInit{},
+		rec:  rec,
+	}
+}
+
+// Register data provider constructors, ws could be nil if the provider has no websocket api
+func (r *Registry) Register(name string, rest RestInit, ws WsInit) {
+	name = strings.ToLower(name)
+	r.rest[name] = rest
+	if ws != nil {
+		r.ws[name] = ws
+	}
+}
+
+// Build rest and ws clients for the selected data providers, the first one becomes the primary provider
+func (r *Registry) Build(names []string, pipe chan *domain.Data, l *log.Logger) (p *Providers, err error) {
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no data provider selected")
+	}
+	p = &Providers{
+		rest:     make(map[string]RestClient, len(names)),
+		ws:       make(map[string]WsClient, len(names)),
+		breakers: make(map[string]*breaker, len(names)),
+	}
+	for _, name := range names {
+		name = strings.ToLower(name)
+		restInit, ok := r.rest[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown data provider %q", name)
+		}
+		if p.rest[name], err = restInit(r.rec); err != nil {
+			return nil, fmt.Errorf("failed to init %s rest client: %w", name, err)
+		}
+		if wsInit, ok := r.ws[name]; ok {
+			if p.ws[name], err = wsInit(pipe, l, r.rec); err != nil {
+				return nil, fmt.Errorf("failed to init %s ws client: %w", name, err)
+			}
+		}
+		p.breakers[name] = &breaker{}
+		p.names = append(p.names, name)
+	}
+	return p, nil
+}
+
+// Providers keeps rest and ws clients of the selected data providers in the order of priority
+type Providers struct {
+	names    []string
+	rest     map[string]RestClient
+	ws       map[string]WsClient
+	breakers map[string]*breaker
+}
+
+// Names of the selected data providers, the first one is the primary provider
+func (p *Providers) Names() []string {
+	return append([]string(nil), p.names...)
+}
+
+// Primary data provider name
+func (p *Providers) Primary() string {
+	return p.names[0]
+}
+
+// IsPresent return true if the data provider with selected name is running
+func (p *Providers) IsPresent(name string) bool {
+	_, ok := p.rest[strings.ToLower(name)]
+	return ok
+}
+
+func (p *Providers) resolve(name string) string {
+	if name == "" {
+		return p.names[0]
+	}
+	return strings.ToLower(name)
+}
+
+// Rest return rest client of the selected data provider or of the primary one if the name is empty
+func (p *Providers) Rest(name string) RestClient {
+	return p.rest[p.resolve(name)]
+}
+
+// Ws return ws client of the selected data provider or of the primary one if the name is empty
+func (p *Providers) Ws(name string) WsClient {
+	return p.ws[p.resolve(name)]
+}
+
+// ListSubscribes return active subscribes of all data providers
+func (p *Providers) ListSubscribes() domain.Subscribes {
+	s := domain.Subscribes{}
+	for _, w := range p.ws {
+		for k, v := range w.ListSubscribes() {
+			s[k] = v
+		}
+	}
+	return s
+}
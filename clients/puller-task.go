@@ -12,6 +12,7 @@ import (
 type Task struct {
 	From     string `json:"from"`
 	To       string `json:"to"`
+	Provider string `json:"provider,omitempty"`
 	Interval int64  `json:"interval"`
 	done     chan struct{}
 }
@@ -14,9 +14,9 @@ import (
 
 // RestApiPuller interface makes it possible to expand the list of rest api pullers
 type RestApiPuller interface {
-	Task(from string, to string) *Task
-	AddTask(from string, to string, interval int64) *Task
-	RemoveTask(from string, to string)
+	Task(from string, to string, provider string) *Task
+	AddTask(from string, to string, provider string, interval int64) *Task
+	RemoveTask(from string, to string, provider string)
 	ListTasks() Tasks
 	UpdateTask(t *Task, interval int64) *Task
 	RestoreLastSession() error
@@ -28,22 +28,22 @@ type RestPuller struct {
 	l        *log.Logger
 	s        db.Session
 	dataPipe chan *domain.Data
-	client   RestClient
+	pr       *Providers
 	pullerMu sync.RWMutex
 }
 
 // NewPuller init rest puller
-func NewPuller(r RestClient, l *log.Logger, s db.Session, dataPipe chan *domain.Data) RestApiPuller {
+func NewPuller(pr *Providers, l *log.Logger, s db.Session, dataPipe chan *domain.Data) RestApiPuller {
 	return &RestPuller{
 		t:        Tasks{},
 		l:        l,
 		s:        s,
 		dataPipe: dataPipe,
-		client:   r,
+		pr:       pr,
 	}
 }
 
-func (p *RestPuller) newTask(from string, to string, interval int64) *Task {
+func (p *RestPuller) newTask(from string, to string, provider string, interval int64) *Task {
 	if interval <= 0 {
 		interval = config.DefaultPullingInterval
 	}
@@ -51,6 +51,7 @@ func (p *RestPuller) newTask(from string, to string, interval int64) *Task {
 		done:     make(chan struct{}),
 		From:     from,
 		To:       to,
+		Provider: strings.ToLower(provider),
 		Interval: interval,
 	}
 }
@@ -66,9 +67,18 @@ func (p *RestPuller) ListTasks() Tasks {
 	return t
 }
 
-// Task return task with selected currencies pair, if possible
-func (p *RestPuller) Task(from, to string) *Task {
-	return p.task(buildTaskName(from, to))
+// Task return task with selected currencies pair and data provider, if possible
+func (p *RestPuller) Task(from, to, provider string) *Task {
+	return p.task(buildTaskName(from, to, p.taskProvider(provider)))
+}
+
+// taskProvider normalizes the data provider of the task, the primary one selected by its name is the same as the
+// empty one, so the same source never gets two tasks
+func (p *RestPuller) taskProvider(provider string) string {
+	if provider = strings.ToLower(provider); p.pr != nil && provider == p.pr.Primary() {
+		return ""
+	}
+	return provider
 }
 
 func (p *RestPuller) task(name string) *Task {
@@ -77,15 +87,20 @@ func (p *RestPuller) task(name string) *Task {
 	return p.t[name]
 }
 
-func buildTaskName(from, to string) string {
-	return strings.ToUpper(fmt.Sprintf("%s:%s", from, to))
+// buildTaskName return FROM:TO for the primary data provider and FROM:TO:PROVIDER for the selected one
+func buildTaskName(from, to, provider string) string {
+	if provider == "" {
+		return strings.ToUpper(fmt.Sprintf("%s:%s", from, to))
+	}
+	return strings.ToUpper(fmt.Sprintf("%s:%s:%s", from, to, provider))
 }
 
-// AddTask to collect data for the selected currency pair to the puller
-func (p *RestPuller) AddTask(from string, to string, interval int64) *Task {
-	t := p.newTask(from, to, interval)
-	t.run(p.client, p.l, p.dataPipe)
-	name := buildTaskName(from, to)
+// AddTask to collect data for the selected currency pair from the selected (or primary) data provider to the puller
+func (p *RestPuller) AddTask(from string, to string, provider string, interval int64) *Task {
+	provider = p.taskProvider(provider)
+	t := p.newTask(from, to, provider, interval)
+	t.run(p.pr.Rest(provider), p.l, p.dataPipe)
+	name := buildTaskName(from, to, provider)
 	p.pullerMu.Lock()
 	p.t[name] = t
 	p.pullerMu.Unlock()
@@ -95,9 +110,9 @@ func (p *RestPuller) AddTask(from string, to string, interval int64) *Task {
 	return t
 }
 
-// RemoveTask from the puller by the selected currency pair
-func (p *RestPuller) RemoveTask(from string, to string) {
-	name := buildTaskName(from, to)
+// RemoveTask from the puller by the selected currency pair and data provider
+func (p *RestPuller) RemoveTask(from string, to string, provider string) {
+	name := buildTaskName(from, to, p.taskProvider(provider))
 	t := p.task(name)
 	t.close()
 	p.pullerMu.Lock()
@@ -118,17 +133,35 @@ func (p *RestPuller) RestoreLastSession() (err error) {
 		return
 	}
 	for k, v := range ses {
-		if pair := strings.Split(k, ":"); len(pair) == 2 {
-			from, to := pair[0], pair[1]
-			p.AddTask(from, to, v)
+		var provider string
+		pair := strings.Split(k, ":")
+		switch len(pair) {
+		case 3:
+			if provider = pair[2]; !p.pr.IsPresent(provider) {
+				p.l.Printf("skip task %s: data provider %s is not running", k, strings.ToLower(provider))
+				continue
+			}
+		case 2:
+		default:
+			continue
+		}
+		if name := buildTaskName(pair[0], pair[1], p.taskProvider(provider)); name != k {
+			// the task of the primary data provider was stored with its name, keep only the normalized one
+			if err := p.s.RemoveTask(k); err != nil {
+				p.l.Println(err)
+			}
+			if _, ok := ses[name]; ok {
+				continue
+			}
 		}
+		p.AddTask(pair[0], pair[1], provider, v)
 	}
 	return
 }
 
 func (p *RestPuller) UpdateTask(t *Task, interval int64) *Task {
 	atomic.StoreInt64(&t.Interval, interval)
-	if err := p.s.UpdateTask(buildTaskName(t.From, t.To), interval); err != nil {
+	if err := p.s.UpdateTask(buildTaskName(t.From, t.To, t.Provider), interval); err != nil {
 		p.l.Println(err)
 	}
 	return t
@@ -0,0 +1,204 @@
+package recorder
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/streamdp/ccd/domain"
+)
+
+// Sources of the recorded frames
+const (
+	Rest = "rest"
+	Ws   = "ws"
+	Tick = "tick" // the converted tick, the payload is domain.Data and could be replayed with the replay provider
+)
+
+const (
+	queueSize     = 4096
+	flushInterval = time.Second
+	rotateAfter   = time.Hour
+)
+
+// Frame raw payload received from the data provider, frames are written one per line to the gzip compressed jsonl
+// files, non-json payloads are written as json strings
+type Frame struct {
+	Time     int64           `json:"time"`
+	Provider string          `json:"provider"`
+	Source   string          `json:"source"`
+	Payload  json.RawMessage `json:"payload"`
+}
+
+// Recorder writes frames to the rotating files in the background, so the read loops of the data providers are not
+// blocked by the disk, frames are dropped when the queue is full. The nil recorder records nothing.
+type Recorder struct {
+	dir     string
+	maxSize int64
+	l       *log.Logger
+	frames  chan *Frame
+	done    chan struct{}
+	stopped chan struct{}
+	once    sync.Once
+
+	f       *os.File
+	gz      *gzip.Writer
+	size    int64
+	opened  time.Time
+	dropped int64
+}
+
+// New recorder writing frames to the dir, files are rotated hourly or when maxSize bytes of frames were written,
+// maxSize 0 means no size limit
+func New(dir string, maxSize int64, l *log.Logger) (rec *Recorder, err error) {
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	rec = &Recorder{
+		dir:     dir,
+		maxSize: maxSize,
+		l:       l,
+		frames:  make(chan *Frame, queueSize),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	if err = rec.rotate(); err != nil {
+		return nil, err
+	}
+	go rec.serve()
+	return rec, nil
+}
+
+// Record the raw payload received from the data provider
+func (rec *Recorder) Record(provider, source string, payload []byte) {
+	if rec == nil {
+		return
+	}
+	f := &Frame{
+		Time:     time.Now().UnixMilli(),
+		Provider: provider,
+		Source:   source,
+	}
+	if json.Valid(payload) {
+		f.Payload = append(json.RawMessage(nil), payload...)
+	} else {
+		f.Payload, _ = json.Marshal(string(payload))
+	}
+	select {
+	case rec.frames <- f:
+	default:
+		atomic.AddInt64(&rec.dropped, 1)
+	}
+}
+
+// RecordTick record the converted tick, so the recording could be replayed with the replay data provider
+func (rec *Recorder) RecordTick(d *domain.Data) {
+	if rec == nil {
+		return
+	}
+	if b, err := json.Marshal(d); err == nil {
+		rec.Record(d.Provider, Tick, b)
+	}
+}
+
+// Close write the queued frames and close the current file, so the gzip trailer is written. Frames recorded after
+// Close are dropped, the next calls do nothing.
+func (rec *Recorder) Close() {
+	if rec == nil {
+		return
+	}
+	rec.once.Do(func() {
+		close(rec.done)
+		<-rec.stopped
+	})
+}
+
+func (rec *Recorder) serve() {
+	tick := time.NewTicker(flushInterval)
+	defer tick.Stop()
+	defer close(rec.stopped)
+	for {
+		select {
+		case <-rec.done:
+			rec.close()
+			return
+		case f := <-rec.frames:
+			if err := rec.write(f); err != nil {
+				rec.l.Println(fmt.Errorf("recorder: %w", err))
+			}
+		case <-tick.C:
+			if dropped := atomic.SwapInt64(&rec.dropped, 0); dropped > 0 {
+				rec.l.Printf("recorder: %d frames dropped, the queue is full", dropped)
+			}
+			if err := rec.gz.Flush(); err != nil {
+				rec.l.Println(fmt.Errorf("recorder: %w", err))
+			}
+			if time.Since(rec.opened) > rotateAfter {
+				if err := rec.rotate(); err != nil {
+					rec.l.Println(fmt.Errorf("recorder: %w", err))
+				}
+			}
+		}
+	}
+}
+
+// close the current file after the queued frames were written
+func (rec *Recorder) close() {
+	for {
+		select {
+		case f := <-rec.frames:
+			if err := rec.write(f); err != nil {
+				rec.l.Println(fmt.Errorf("recorder: %w", err))
+			}
+		default:
+			if err := rec.gz.Close(); err != nil {
+				rec.l.Println(fmt.Errorf("recorder: %w", err))
+			}
+			if err := rec.f.Close(); err != nil {
+				rec.l.Println(fmt.Errorf("recorder: %w", err))
+			}
+			return
+		}
+	}
+}
+
+func (rec *Recorder) write(f *Frame) error {
+	b, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	if rec.maxSize > 0 && rec.size+int64(len(b)) > rec.maxSize && rec.size > 0 {
+		if err = rec.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := rec.gz.Write(append(b, '\n'))
+	rec.size += int64(n)
+	return err
+}
+
+// rotate close the current file and open the new one
+func (rec *Recorder) rotate() (err error) {
+	if rec.gz != nil {
+		if err = rec.gz.Close(); err != nil {
+			rec.l.Println(fmt.Errorf("recorder: %w", err))
+		}
+		if err = rec.f.Close(); err != nil {
+			rec.l.Println(fmt.Errorf("recorder: %w", err))
+		}
+	}
+	rec.opened = time.Now()
+	name := filepath.Join(rec.dir, fmt.Sprintf("frames-%s.jsonl.gz", rec.opened.UTC().Format("20060102T150405.000")))
+	if rec.f, err = os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644); err != nil {
+		return err
+	}
+	rec.gz = gzip.NewWriter(rec.f)
+	rec.size = 0
+	return nil
+}
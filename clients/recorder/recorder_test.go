@@ -0,0 +1,71 @@
+package recorder
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/streamdp/ccd/domain"
+)
+
+func TestRecordClose(t *testing.T) {
+	dir := t.TempDir()
+	rec, err := New(dir, 0, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.Record("huobi", Ws, []byte("not json"))
+	rec.RecordTick(&domain.Data{FromSymbol: "BTC", ToSymbol: "USD", Price: 1, Provider: "huobi"})
+	rec.Close()
+	// the second close does nothing and the frames recorded after close are dropped
+	rec.Close()
+	rec.Record("huobi", Ws, []byte("{}"))
+
+	names, err := filepath.Glob(filepath.Join(dir, "*.jsonl.gz"))
+	if err != nil || len(names) != 1 {
+		t.Fatalf("got files %v (%v), want one file", names, err)
+	}
+	f, err := os.Open(names[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// reading up to EOF fails if the gzip trailer wasn't written
+	b, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d frames, want 2", len(lines))
+	}
+	frame := &Frame{}
+	if err = json.Unmarshal([]byte(lines[1]), frame); err != nil {
+		t.Fatal(err)
+	}
+	d := &domain.Data{}
+	if err = json.Unmarshal(frame.Payload, d); err != nil {
+		t.Fatal(err)
+	}
+	if frame.Source != Tick || frame.Provider != "huobi" || d.FromSymbol != "BTC" || d.Price != 1 {
+		t.Errorf("got unexpected frame %+v", frame)
+	}
+}
+
+func TestNilRecorder(t *testing.T) {
+	var rec *Recorder
+	rec.Record("huobi", Ws, []byte("{}"))
+	rec.RecordTick(&domain.Data{})
+	rec.Close()
+}
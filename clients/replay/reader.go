@@ -0,0 +1,216 @@
+package replay
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/streamdp/ccd/clients/recorder"
+	"github.com/streamdp/ccd/domain"
+)
+
+// load recorded ticks from the jsonl file (one domain.Data or one recorder.Frame per line) or from the csv file with
+// a header row of the domain.Data json field names, the files could be gzip compressed like the recorder files
+func load(name string) (ticks []*domain.Data, err error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	var r io.Reader = f
+	ext := strings.ToLower(filepath.Ext(name))
+	if ext == ".gz" {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		defer func() {
+			_ = gz.Close()
+		}()
+		r = gz
+		ext = strings.ToLower(filepath.Ext(strings.TrimSuffix(name, filepath.Ext(name))))
+	}
+	if ext == ".csv" {
+		ticks, err = readCsv(r)
+	} else {
+		ticks, err = readJsonl(r)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	if len(ticks) == 0 {
+		return nil, fmt.Errorf("%s has no ticks", name)
+	}
+	return ticks, nil
+}
+
+func readJsonl(r io.Reader) (ticks []*domain.Data, err error) {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; s.Scan(); line++ {
+		b := s.Bytes()
+		if len(strings.TrimSpace(string(b))) == 0 {
+			continue
+		}
+		var d *domain.Data
+		if d, err = parseLine(b); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if d != nil {
+			ticks = append(ticks, d)
+		}
+	}
+	return ticks, s.Err()
+}
+
+// parseLine return the tick of the line, the recorder frames of the raw payloads have no tick and are skipped
+func parseLine(b []byte) (d *domain.Data, err error) {
+	f := &recorder.Frame{}
+	if err = json.Unmarshal(b, f); err != nil {
+		return nil, err
+	}
+	switch {
+	case f.Source == "":
+		// not a frame, the line is the tick itself
+	case f.Source == recorder.Tick:
+		b = f.Payload
+	default:
+		return nil, nil
+	}
+	d = &domain.Data{}
+	if err = json.Unmarshal(b, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func readCsv(r io.Reader) (ticks []*domain.Data, err error) {
+	c := csv.NewReader(r)
+	header, err := c.Read()
+	if err != nil {
+		return nil, err
+	}
+	for line := 2; ; line++ {
+		var record []string
+		if record, err = c.Read(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return ticks, nil
+			}
+			return nil, err
+		}
+		d := &domain.Data{}
+		for i, v := range record {
+			if i >= len(header) {
+				break
+			}
+			if err = setField(d, strings.TrimSpace(header[i]), strings.TrimSpace(v)); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+		ticks = append(ticks, d)
+	}
+}
+
+func setField(d *domain.Data, name, v string) (err error) {
+	var f float64
+	switch name {
+	case "from_sym":
+		d.FromSymbol = v
+		return
+	case "to_sym":
+		d.ToSymbol = v
+		return
+	case "provider":
+		d.Provider = v
+		return
+	case "display_data_raw":
+		d.DisplayDataRaw = v
+		return
+	case "id":
+		return
+	}
+	if v == "" {
+		return
+	}
+	if name == "last_update" {
+		d.LastUpdate, err = strconv.ParseInt(v, 10, 64)
+		return
+	}
+	if f, err = strconv.ParseFloat(v, 64); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	switch name {
+	case "change_24_hour":
+		d.Change24Hour = f
+	case "change_pct_24_hour":
+		d.ChangePct24Hour = f
+	case "open_24_hour":
+		d.Open24Hour = f
+	case "volume_24_hour":
+		d.Volume24Hour = f
+	case "low_24_hour":
+		d.Low24Hour = f
+	case "high_24_hour":
+		d.High24Hour = f
+	case "price":
+		d.Price = f
+	case "supply":
+		d.Supply = f
+	case "mkt_cap":
+		d.MktCap = f
+	default:
+		return fmt.Errorf("unknown column %q", name)
+	}
+	return
+}
+
+// replayed copy of the recorded tick with the shifted last update time in milliseconds, the provider is replaced by
+// "replay", so the replayed ticks never collide with the ones of the real provider
+func replayed(d *domain.Data, lastUpdate int64) *domain.Data {
+	c := *d
+	c.Id = 0
+	c.LastUpdate = lastUpdate
+	c.Provider = Name
+	return &c
+}
+
+// timeline shifts the recorded times by a constant offset, so the earliest recorded tick happens at the start of the
+// replay. Every next pass over the recording is shifted by the length of the recording and one more second, so the
+// replayed times never go back and the ticks of the passes don't collide.
+type timeline struct {
+	offset int64
+	span   int64
+}
+
+func newTimeline(ticks []*domain.Data, now time.Time) *timeline {
+	var first, last int64
+	for i, t := range ticks {
+		ms := t.UpdatedAt().UnixMilli()
+		if i == 0 || ms < first {
+			first = ms
+		}
+		if i == 0 || ms > last {
+			last = ms
+		}
+	}
+	return &timeline{
+		offset: now.UnixMilli() - first,
+		span:   last - first + time.Second.Milliseconds(),
+	}
+}
+
+// at return the replayed time of the tick in milliseconds during the pass over the recording, starting from 0
+func (tl *timeline) at(d *domain.Data, pass int) int64 {
+	return d.UpdatedAt().UnixMilli() + tl.offset + int64(pass)*tl.span
+}
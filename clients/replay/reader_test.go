@@ -0,0 +1,98 @@
+package replay
+
+import (
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/streamdp/ccd/domain"
+)
+
+func TestTimeline(t *testing.T) {
+	ticks := []*domain.Data{
+		{FromSymbol: "BTC", ToSymbol: "USD", LastUpdate: 1705313045123},
+		{FromSymbol: "BTC", ToSymbol: "USD", LastUpdate: 1705313045789},
+		// the seconds are normalized to milliseconds
+		{FromSymbol: "BTC", ToSymbol: "USD", LastUpdate: 1705313050},
+	}
+	now := time.UnixMilli(1800000000000)
+	tl := newTimeline(ticks, now)
+	want := []int64{1800000000000, 1800000000666, 1800000004877}
+	for i, tick := range ticks {
+		if got := tl.at(tick, 0); got != want[i] {
+			t.Errorf("tick %d replayed at %d, want %d", i, got, want[i])
+		}
+	}
+	// the next pass starts one second after the last tick of the previous one
+	if got := tl.at(ticks[0], 1); got != 1800000005877 {
+		t.Errorf("second pass replayed at %d, want 1800000005877", got)
+	}
+}
+
+func TestRestGet(t *testing.T) {
+	ticks := []*domain.Data{
+		{Id: 7, FromSymbol: "BTC", ToSymbol: "USD", Price: 1, LastUpdate: 1705313045000, Provider: "binance"},
+		{Id: 8, FromSymbol: "BTC", ToSymbol: "USD", Price: 2, LastUpdate: 1705313047000, Provider: "binance"},
+	}
+	r := &replayRest{
+		ticks:    map[string][]*domain.Data{"BTC:USD": ticks},
+		cursor:   map[string]int{},
+		timeline: newTimeline(ticks, time.UnixMilli(1800000000000)),
+	}
+	want := []struct {
+		price      float64
+		lastUpdate int64
+	}{
+		{1, 1800000000000},
+		{2, 1800000002000},
+		{1, 1800000003000},
+	}
+	for _, w := range want {
+		d, err := r.Get("btc", "usd")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.Price != w.price || d.LastUpdate != w.lastUpdate || d.Id != 0 || d.Provider != Name {
+			t.Errorf("got %+v, want price %v at %d", d, w.price, w.lastUpdate)
+		}
+	}
+	if ticks[0].Provider != "binance" || ticks[0].LastUpdate != 1705313045000 {
+		t.Error("the recorded tick was modified")
+	}
+	if _, err := r.Get("ETH", "USD"); err == nil {
+		t.Error("no error for the pair without ticks")
+	}
+}
+
+func TestLoadRecorderFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "frames.jsonl.gz")
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(f)
+	_, err = gz.Write([]byte(`{"time":1705313045200,"provider":"binance","source":"ws","payload":{"stream":"x"}}
+{"time":1705313045201,"provider":"binance","source":"tick","payload":{"from_sym":"BTC","to_sym":"USD",` +
+		`"price":43183.4,"last_update":1705313045123,"provider":"binance"}}
+{"time":1705313045300,"provider":"huobi","source":"rest","payload":"not json"}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	ticks, err := load(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ticks) != 1 || ticks[0].FromSymbol != "BTC" || ticks[0].Price != 43183.4 ||
+		ticks[0].LastUpdate != 1705313045123 {
+		t.Errorf("got unexpected ticks %+v", ticks)
+	}
+}
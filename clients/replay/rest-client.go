@@ -0,0 +1,72 @@
+package replay
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/streamdp/ccd/clients"
+	"github.com/streamdp/ccd/clients/recorder"
+	"github.com/streamdp/ccd/config"
+	"github.com/streamdp/ccd/domain"
+)
+
+// Name of the data provider
+const Name = "replay"
+
+type replayRest struct {
+	ticks    map[string][]*domain.Data
+	cursor   map[string]int
+	timeline *timeline
+	mu       sync.Mutex
+}
+
+func getReplayFile() (name string, err error) {
+	if name = config.ReplayFile; name == "" {
+		return "", errors.New("you should specify the recorded ticks file with -replay flag or \"CCDC_REPLAYFILE\"" +
+			" in you OS environment")
+	}
+	return
+}
+
+func pairName(from, to string) string {
+	return strings.ToUpper(fmt.Sprintf("%s:%s", from, to))
+}
+
+// Init load the recorded ticks, every Get returns the next recorded tick of the pair and starts over at the end
+func Init(_ *recorder.Recorder) (clients.RestClient, error) {
+	name, err := getReplayFile()
+	if err != nil {
+		return nil, err
+	}
+	ticks, err := load(name)
+	if err != nil {
+		return nil, err
+	}
+	r := &replayRest{
+		ticks:    map[string][]*domain.Data{},
+		cursor:   map[string]int{},
+		timeline: newTimeline(ticks, time.Now()),
+	}
+	for _, t := range ticks {
+		p := pairName(t.FromSymbol, t.ToSymbol)
+		r.ticks[p] = append(r.ticks[p], t)
+	}
+	return r, nil
+}
+
+func (r *replayRest) Get(fSym string, tSym string) (*domain.Data, error) {
+	p := pairName(fSym, tSym)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ticks := r.ticks[p]
+	if len(ticks) == 0 {
+		return nil, fmt.Errorf("no recorded ticks for %s", p)
+	}
+	i := r.cursor[p]
+	r.cursor[p] = i + 1
+	t := ticks[i%len(ticks)]
+	return replayed(t, r.timeline.at(t, i/len(ticks))), nil
+}
@@ -0,0 +1,111 @@
+package replay
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/streamdp/ccd/clients"
+	"github.com/streamdp/ccd/clients/recorder"
+	"github.com/streamdp/ccd/config"
+	"github.com/streamdp/ccd/domain"
+)
+
+// maxGap limits the pause between two recorded ticks, so gaps in the recording don't stall the replay
+const maxGap = time.Minute
+
+type replayWs struct {
+	l          *log.Logger
+	ticks      []*domain.Data
+	speed      float64
+	subscribes domain.Subscribes
+	subMu      sync.RWMutex
+}
+
+// InitWs load the recorded ticks and push the ticks of the subscribed pairs to the pipe in a loop, keeping the
+// original pauses between ticks divided by the replay speed (0 means no pauses at all)
+func InitWs(pipe chan *domain.Data, l *log.Logger, _ *recorder.Recorder) (clients.WsClient, error) {
+	name, err := getReplayFile()
+	if err != nil {
+		return nil, err
+	}
+	ticks, err := load(name)
+	if err != nil {
+		return nil, err
+	}
+	r := &replayWs{
+		l:          l,
+		ticks:      ticks,
+		speed:      config.ReplaySpeed,
+		subscribes: domain.Subscribes{},
+	}
+	r.replay(pipe)
+	return r, nil
+}
+
+func (r *replayWs) replay(pipe chan *domain.Data) {
+	go func() {
+		tl := newTimeline(r.ticks, time.Now())
+		for pass := 0; ; pass++ {
+			var sent int
+			for i, t := range r.ticks {
+				if i > 0 {
+					time.Sleep(r.pause(r.ticks[i-1], t))
+				}
+				if r.isSubscribed(t.FromSymbol, t.ToSymbol) {
+					pipe <- replayed(t, tl.at(t, pass))
+					sent++
+				}
+			}
+			if sent == 0 {
+				// nothing is subscribed, don't spin over the recording without pauses
+				time.Sleep(time.Second)
+			}
+		}
+	}()
+}
+
+func (r *replayWs) pause(prev, next *domain.Data) time.Duration {
+	if r.speed <= 0 {
+		return 0
+	}
+	gap := next.UpdatedAt().Sub(prev.UpdatedAt())
+	if gap < 0 {
+		return 0
+	}
+	if gap > maxGap {
+		gap = maxGap
+	}
+	return time.Duration(float64(gap) / r.speed)
+}
+
+func (r *replayWs) isSubscribed(from, to string) bool {
+	r.subMu.RLock()
+	defer r.subMu.RUnlock()
+	_, ok := r.subscribes[pairName(from, to)]
+	return ok
+}
+
+func (r *replayWs) Subscribe(from, to string) error {
+	r.subMu.Lock()
+	defer r.subMu.Unlock()
+	r.subscribes[pairName(from, to)] = domain.NewSubscribe(from, to, 0)
+	return nil
+}
+
+func (r *replayWs) Unsubscribe(from, to string) error {
+	r.subMu.Lock()
+	defer r.subMu.Unlock()
+	delete(r.subscribes, pairName(from, to))
+	return nil
+}
+
+func (r *replayWs) ListSubscribes() domain.Subscribes {
+	s := make(domain.Subscribes, len(r.subscribes))
+	r.subMu.RLock()
+	defer r.subMu.RUnlock()
+	for k, v := range r.subscribes {
+		s[k] = v
+	}
+	return s
+}
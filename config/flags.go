@@ -4,6 +4,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -14,11 +15,29 @@ const DefaultPullingInterval = 60
 var (
 	// Port - set default port for gin-gonic engine init
 	Port              = ":8080"
+	GrpcPort          = "" // empty means the gRPC server is disabled
 	RunMode           = gin.DebugMode
 	HttpClientTimeout = 1000
 	Version           = "1.0.0"
-	DataProvider      = "cryptocompare" // "huobi"
+	DataProvider      = "cryptocompare" // "huobi", "binance", "kraken", "coinbase" or a list "cryptocompare,huobi"
 	SessionStore      = "db"            // "redis"
+	GenericConfig     = ""              // path to the mapping file of the "generic" data provider
+	ReplayFile        = ""              // path to the recorded ticks of the "replay" data provider
+	ReplaySpeed       = 1.0             // replay speed multiplier, 0 means without pauses
+	RecordDir         = ""              // directory for the raw data provider payloads, empty means no recording
+	RecordMaxSize     = 64              // size of the record file in megabytes before rotation, 0 means no limit
+	BatchSize         = 500             // max number of rows inserted into the database at once
+	FlushInterval     = 1000            // max time in milliseconds the rows wait in the write queue
+	QueueSize         = 10000           // size of the write queue
+	Overflow          = "block"         // overflow policy of the write queue "block", "drop-oldest" or "spill"
+	Migrate           = "up"            // "up" applies pending migrations on startup, "down" reverts them, "none"
+	MigrateSteps      = 1               // number of the migrations to revert with the "down" migrate
+	SpillDir          = "data"          // directory for the rows spilled to disk and the rows failed to insert
+	Timescale         = "auto"          // "auto" uses the timescaledb hypertable when the extension is installed, "off"
+	TsCompressAfter   = 7               // days before the hypertable chunks are compressed, 0 disables compression
+	TsRetention       = 0               // days the hypertable keeps the raw data, 0 keeps it forever
+	Retention         = ""              // retention policies "PAIR=raw,minute,hour;..." in days, empty disables the job
+	RetentionInterval = 60              // minutes between the retention job runs
 )
 
 // ParseFlags and update config variables
@@ -32,21 +51,120 @@ func ParseFlags() {
 	flag.BoolVar(&showVersion, "v", false, "display version")
 	flag.BoolVar(&debug, "debug", false, "run the program in debug mode")
 	flag.StringVar(&Port, "port", ":8080", "set specify port")
+	flag.StringVar(&GrpcPort, "grpc", GrpcPort, "set specify port of the gRPC server, empty to disable it")
 	flag.StringVar(&SessionStore, "session", "db", "set session store \"db\" or \"redis\"")
 	flag.IntVar(&HttpClientTimeout, "timeout", HttpClientTimeout, "how long to wait for a response from the"+
 		" api server before sending data from the cache")
 	flag.StringVar(&DataProvider, "dataprovider", DataProvider, "use selected data provider"+
-		" (\"cryptocompare\", \"huobi\")")
+		" (\"cryptocompare\", \"huobi\", \"binance\", \"kraken\", \"coinbase\", \"generic\", \"replay\"), pass a"+
+		" comma separated list to run several providers, the first one is the primary")
+	flag.StringVar(&GenericConfig, "generic", GenericConfig, "path to the json/yaml mapping file of the"+
+		" \"generic\" data provider")
+	flag.StringVar(&ReplayFile, "replay", ReplayFile, "path to the jsonl/csv file with recorded ticks of the"+
+		" \"replay\" data provider")
+	flag.Float64Var(&ReplaySpeed, "replayspeed", ReplaySpeed, "replay speed multiplier, 0 means without pauses")
+	flag.StringVar(&RecordDir, "record", RecordDir, "record raw data provider payloads and ticks to the"+
+		" selected directory")
+	flag.IntVar(&RecordMaxSize, "recordsize", RecordMaxSize, "rotate record files after the selected size in"+
+		" megabytes, 0 means no size limit")
+	flag.IntVar(&BatchSize, "batchsize", BatchSize, "max number of rows inserted into the database at once")
+	flag.IntVar(&FlushInterval, "flushinterval", FlushInterval, "max time in milliseconds the rows wait in the"+
+		" write queue before insert")
+	flag.IntVar(&QueueSize, "queuesize", QueueSize, "size of the write queue")
+	flag.StringVar(&Overflow, "overflow", Overflow, "what to do when the write queue is full: \"block\" the"+
+		" producers, \"drop-oldest\" rows or \"spill\" them to disk")
+	flag.StringVar(&SpillDir, "spilldir", SpillDir, "directory for the rows spilled to disk and the"+
+		" rows failed to insert")
+	flag.StringVar(&Migrate, "migrate", Migrate, "\"up\" applies pending database migrations on startup,"+
+		" \"down\" reverts the last ones and exits, \"none\" skips migrations")
+	flag.IntVar(&MigrateSteps, "steps", MigrateSteps, "number of the migrations to revert with -migrate down")
+	flag.StringVar(&Timescale, "timescale", Timescale, "\"auto\" turns the postgres data table into the timescaledb"+
+		" hypertable with the candle aggregates when the extension is installed, \"off\" disables it")
+	flag.IntVar(&TsCompressAfter, "tscompress", TsCompressAfter, "compress the hypertable chunks older than the"+
+		" selected days, 0 disables compression")
+	flag.IntVar(&TsRetention, "tsretention", TsRetention, "drop the raw data of the hypertable older than the"+
+		" selected days, the candle aggregates are kept, 0 keeps the data forever")
+	flag.StringVar(&Retention, "retention", Retention, "retention policies of the stored data like"+
+		" \"*=7,90,0;BTC:USD=30,365,0\", the days the raw ticks, the minute and the hourly rows are kept for the pair"+
+		" or for all the other pairs \"*\", 0 keeps them forever")
+	flag.IntVar(&RetentionInterval, "retentioninterval", RetentionInterval, "minutes between the retention job runs")
 	flag.Parse()
 	if GetEnv("CCDC_DEBUG") != "" {
 		debug = true
 	}
+	if grpcPort := GetEnv("CCDC_GRPCPORT"); grpcPort != "" {
+		GrpcPort = grpcPort
+	}
 	if dataProvider := GetEnv("CCDC_DATAPROVIDER"); dataProvider != "" {
 		DataProvider = strings.ToLower(dataProvider)
 	}
 	if sessionStore := GetEnv("CCDC_SESSIONSTORE"); sessionStore != "" {
 		SessionStore = strings.ToLower(sessionStore)
 	}
+	if genericConfig := GetEnv("CCDC_GENERICCONFIG"); genericConfig != "" {
+		GenericConfig = genericConfig
+	}
+	if replayFile := GetEnv("CCDC_REPLAYFILE"); replayFile != "" {
+		ReplayFile = replayFile
+	}
+	if replaySpeed := GetEnv("CCDC_REPLAYSPEED"); replaySpeed != "" {
+		if speed, err := strconv.ParseFloat(replaySpeed, 64); err == nil {
+			ReplaySpeed = speed
+		}
+	}
+	if recordDir := GetEnv("CCDC_RECORDDIR"); recordDir != "" {
+		RecordDir = recordDir
+	}
+	if recordSize := GetEnv("CCDC_RECORDSIZE"); recordSize != "" {
+		if size, err := strconv.Atoi(recordSize); err == nil {
+			RecordMaxSize = size
+		}
+	}
+	if batchSize := GetEnv("CCDC_BATCHSIZE"); batchSize != "" {
+		if size, err := strconv.Atoi(batchSize); err == nil {
+			BatchSize = size
+		}
+	}
+	if flushInterval := GetEnv("CCDC_FLUSHINTERVAL"); flushInterval != "" {
+		if interval, err := strconv.Atoi(flushInterval); err == nil {
+			FlushInterval = interval
+		}
+	}
+	if queueSize := GetEnv("CCDC_QUEUESIZE"); queueSize != "" {
+		if size, err := strconv.Atoi(queueSize); err == nil {
+			QueueSize = size
+		}
+	}
+	if overflow := GetEnv("CCDC_OVERFLOW"); overflow != "" {
+		Overflow = strings.ToLower(overflow)
+	}
+	if spillDir := GetEnv("CCDC_SPILLDIR"); spillDir != "" {
+		SpillDir = spillDir
+	}
+	if migrate := GetEnv("CCDC_MIGRATE"); migrate != "" {
+		Migrate = strings.ToLower(migrate)
+	}
+	if timescale := GetEnv("CCDC_TIMESCALE"); timescale != "" {
+		Timescale = strings.ToLower(timescale)
+	}
+	if compressAfter := GetEnv("CCDC_TSCOMPRESS"); compressAfter != "" {
+		if days, err := strconv.Atoi(compressAfter); err == nil {
+			TsCompressAfter = days
+		}
+	}
+	if retention := GetEnv("CCDC_TSRETENTION"); retention != "" {
+		if days, err := strconv.Atoi(retention); err == nil {
+			TsRetention = days
+		}
+	}
+	if retention := GetEnv("CCDC_RETENTION"); retention != "" {
+		Retention = retention
+	}
+	if retentionInterval := GetEnv("CCDC_RETENTIONINTERVAL"); retentionInterval != "" {
+		if interval, err := strconv.Atoi(retentionInterval); err == nil {
+			RetentionInterval = interval
+		}
+	}
 	if showHelp {
 		fmt.Println("ccd is a microservice that collect data from several crypto data providers using its API.")
 		fmt.Println("")
@@ -62,6 +180,20 @@ func ParseFlags() {
 	}
 }
 
+// DataProviders return the list of selected data providers, the first one is the primary
+func DataProviders() (providers []string) {
+	seen := map[string]struct{}{}
+	for _, p := range strings.Split(DataProvider, ",") {
+		p = strings.ToLower(strings.TrimSpace(p))
+		if _, ok := seen[p]; ok || p == "" {
+			continue
+		}
+		seen[p] = struct{}{}
+		providers = append(providers, p)
+	}
+	return
+}
+
 // GetEnv values for selected name or return null
 func GetEnv(name string) (result string) {
 	return localEnvs.get(name)
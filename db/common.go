@@ -6,10 +6,14 @@ import (
 	"log"
 	"strings"
 
+	"github.com/streamdp/ccd/clients/recorder"
 	"github.com/streamdp/ccd/config"
+	"github.com/streamdp/ccd/db/memory"
 	"github.com/streamdp/ccd/db/mysql"
 	"github.com/streamdp/ccd/db/postgres"
+	"github.com/streamdp/ccd/db/sqlite"
 	"github.com/streamdp/ccd/domain"
+	"github.com/streamdp/ccd/hub"
 )
 
 // Session interface makes it possible to expand the list of session storages
@@ -23,8 +27,15 @@ type Session interface {
 // Database interface makes it possible to expand the list of data storages
 type Database interface {
 	Insert(data *domain.Data) (result sql.Result, err error)
+	InsertBatch(data []*domain.Data) (result sql.Result, err error)
+	InsertMissing(data []*domain.Data) (inserted int64, err error)
 	GetLast(from string, to string) (result *domain.Data, err error)
+	History(from, to string, start, end, cursor int64, limit int) (result []*domain.Data, err error)
+	Since(from, to string, since, cursor int64, limit int) (result []*domain.Data, err error)
+	Candles(from, to string, interval, start, end int64, limit int) (result []*domain.Candle, err error)
 	DataPipe() chan *domain.Data
+	Pairs() (pairs []string, err error)
+	Downsample(from, to string, before, bucket int64) (deleted int64, err error)
 
 	AddSymbol(s string, u string) (result sql.Result, err error)
 	UpdateSymbol(s string, u string) (result sql.Result, err error)
@@ -35,8 +46,22 @@ type Database interface {
 	UpdateTask(n string, i int64) (result sql.Result, err error)
 	RemoveTask(n string) (result sql.Result, err error)
 	GetSession() (tasks map[string]int64, err error)
+
+	Alerts() (alerts []*domain.Alert, err error)
+	AddAlert(a *domain.Alert) (id int64, err error)
+	UpdateAlert(a *domain.Alert) (result sql.Result, err error)
+	RemoveAlert(id int64) (result sql.Result, err error)
+	AddDelivery(l *domain.Delivery) (result sql.Result, err error)
+	Deliveries(alertId int64, limit int) (result []*domain.Delivery, err error)
+
+	Webhooks() (webhooks []*domain.Webhook, err error)
+	AddWebhook(w *domain.Webhook) (id int64, err error)
+	UpdateWebhook(w *domain.Webhook) (result sql.Result, err error)
+	RemoveWebhook(id int64) (result sql.Result, err error)
 }
 
+// Connect to the database selected by the "CCDC_DATABASEURL" and apply the pending migrations if selected by the
+// "-migrate" flag
 func Connect(l *log.Logger) (d Database, err error) {
 	var (
 		driverName       = mysql.Mysql
@@ -53,22 +78,67 @@ func Connect(l *log.Logger) (d Database, err error) {
 	switch driverName {
 	case postgres.Postgres:
 		d, err = postgres.Connect(dataBaseUrl)
+	case sqlite.Sqlite:
+		d, err = sqlite.Connect(connectionString)
+	case memory.Memory:
+		d = memory.New()
 	case mysql.Mysql:
 		fallthrough
 	default:
 		d, err = mysql.Connect(connectionString)
 	}
-	if err == nil {
-		serve(d, l)
+	if err != nil {
+		return nil, err
+	}
+	if err = migrate(d, l); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Serve the data pipe of the database, every data item is recorded, published to the hub, inserted into the database
+// and published to the sinks selected by the "CCDC_SINK". Close the returned writer on exit to store the queued data.
+func Serve(d Database, l *log.Logger, h *hub.Hub, rec *recorder.Recorder) (w *Writer, err error) {
+	sinks, err := newSinks(l)
+	if err != nil {
+		return nil, err
 	}
-	return
+	if w, err = newWriter(d, l, sinks); err != nil {
+		return nil, err
+	}
+	serve(d, h, w, rec)
+	return w, nil
 }
 
-func serve(d Database, l *log.Logger) {
+// serve the data pipe, the data is published to the hub at once and pushed to the write queue according to its
+// overflow policy. The ticks are recorded if the recorder is enabled. The rows already sent to the pipe are still
+// queued when the writer is closed, then the queue is closed, so the writer flushes the last batch.
+func serve(d Database, h *hub.Hub, w *Writer, rec *recorder.Recorder) {
+	go w.run()
 	go func() {
-		for data := range d.DataPipe() {
-			if _, err := d.Insert(data); err != nil {
-				l.Println(err)
+		defer close(w.queue)
+		pipe := d.DataPipe()
+		accept := func(data *domain.Data) {
+			rec.RecordTick(data)
+			h.Publish(data)
+			w.push(data)
+		}
+		for {
+			select {
+			case data, ok := <-pipe:
+				if !ok {
+					return
+				}
+				accept(data)
+			case <-w.done:
+				for n := len(pipe); n > 0; n-- {
+					data, ok := <-pipe
+					if !ok {
+						return
+					}
+					accept(data)
+				}
+				return
 			}
 		}
 	}()
@@ -0,0 +1,459 @@
+// Package dbtest is the conformance suite of the db.Database implementations, every backend must pass it, e.g.:
+//
+//	func TestConformance(t *testing.T) {
+//		dbtest.Run(t, memory.New())
+//	}
+//
+// The suite uses its own symbols, task, alerts and webhooks and removes them at the end, so it could run against a
+// migrated database with the real data too.
+package dbtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/streamdp/ccd/db"
+	"github.com/streamdp/ccd/domain"
+)
+
+const (
+	from     = "DBTESTF"
+	to       = "DBTESTT"
+	symbol   = "DBTESTS"
+	task     = "DBTESTF:DBTESTT"
+	provider = "dbtest"
+	base     = 1699999980 // start of the minute, so the ticks fall into the known candle buckets
+)
+
+// Run the conformance suite against the database
+func Run(t *testing.T, d db.Database) {
+	for _, s := range []string{from, to, symbol} {
+		_, _ = d.RemoveSymbol(s)
+	}
+	_, _ = d.RemoveTask(task)
+	for _, s := range []string{from, to} {
+		if _, err := d.AddSymbol(s, ""); err != nil {
+			t.Fatalf("add symbol %s: %v", s, err)
+		}
+	}
+	t.Cleanup(func() {
+		for _, s := range []string{from, to, symbol} {
+			_, _ = d.RemoveSymbol(s)
+		}
+		_, _ = d.RemoveTask(task)
+	})
+	if d.DataPipe() == nil {
+		t.Fatal("data pipe is nil")
+	}
+	t.Run("Symbols", func(t *testing.T) { testSymbols(t, d) })
+	t.Run("Session", func(t *testing.T) { testSession(t, d) })
+	t.Run("Data", func(t *testing.T) { testData(t, d) })
+	t.Run("History", func(t *testing.T) { testHistory(t, d) })
+	t.Run("Since", func(t *testing.T) { testSince(t, d) })
+	t.Run("Candles", func(t *testing.T) { testCandles(t, d) })
+	t.Run("Alerts", func(t *testing.T) { testAlerts(t, d) })
+	t.Run("Webhooks", func(t *testing.T) { testWebhooks(t, d) })
+	t.Run("Retention", func(t *testing.T) { testRetention(t, d) })
+}
+
+func testSymbols(t *testing.T, d db.Database) {
+	if _, err := d.AddSymbol("", ""); err == nil {
+		t.Error("empty symbol added")
+	}
+	if _, err := d.AddSymbol(symbol, "¤"); err != nil {
+		t.Fatalf("add symbol: %v", err)
+	}
+	if _, err := d.AddSymbol(symbol, "¤"); err == nil {
+		t.Error("duplicate symbol added")
+	}
+	if s := findSymbol(t, d, symbol); s == nil || s.Unicode != '¤' {
+		t.Errorf("got symbol %+v, want unicode ¤", s)
+	}
+	if _, err := d.UpdateSymbol(symbol, "₸"); err != nil {
+		t.Fatalf("update symbol: %v", err)
+	}
+	if s := findSymbol(t, d, symbol); s == nil || s.Unicode != '₸' {
+		t.Errorf("got updated symbol %+v, want unicode ₸", s)
+	}
+	if _, err := d.RemoveSymbol(symbol); err != nil {
+		t.Fatalf("remove symbol: %v", err)
+	}
+	if s := findSymbol(t, d, symbol); s != nil {
+		t.Errorf("removed symbol %+v is still listed", s)
+	}
+}
+
+func findSymbol(t *testing.T, d db.Database, s string) *domain.Symbol {
+	symbols, err := d.Symbols()
+	if err != nil {
+		t.Fatalf("symbols: %v", err)
+	}
+	for _, symbol := range symbols {
+		if symbol.Symbol == s {
+			return symbol
+		}
+	}
+	return nil
+}
+
+func testSession(t *testing.T, d db.Database) {
+	if _, err := d.AddTask("", 60); err == nil {
+		t.Error("empty task added")
+	}
+	if _, err := d.AddTask(task, 60); err != nil {
+		t.Fatalf("add task: %v", err)
+	}
+	if _, err := d.AddTask(task, 120); err != nil {
+		t.Fatalf("add existing task: %v", err)
+	}
+	if i := sessionTask(t, d); i != 60 {
+		t.Errorf("got interval %d after adding the existing task, want 60", i)
+	}
+	if _, err := d.UpdateTask(task, 90); err != nil {
+		t.Fatalf("update task: %v", err)
+	}
+	if i := sessionTask(t, d); i != 90 {
+		t.Errorf("got interval %d, want 90", i)
+	}
+	if _, err := d.RemoveTask(task); err != nil {
+		t.Fatalf("remove task: %v", err)
+	}
+	if i := sessionTask(t, d); i != 0 {
+		t.Errorf("removed task is still in the session with interval %d", i)
+	}
+}
+
+func sessionTask(t *testing.T, d db.Database) int64 {
+	tasks, err := d.GetSession()
+	if err != nil {
+		t.Fatalf("get session: %v", err)
+	}
+	return tasks[task]
+}
+
+// tick of the test pair, offset is in seconds from the base
+func tick(offset int64, price, volume float64) *domain.Data {
+	return &domain.Data{
+		FromSymbol:   from,
+		ToSymbol:     to,
+		Price:        price,
+		Volume24Hour: volume,
+		LastUpdate:   base + offset,
+		Provider:     provider,
+	}
+}
+
+// testData leaves six ticks of the pair, two in every minute since the base: 100, 101 | 102, 103 | 200, 105
+func testData(t *testing.T, d db.Database) {
+	if _, err := d.Insert(nil); err == nil {
+		t.Error("empty data inserted")
+	}
+	if _, err := d.InsertBatch(nil); err == nil {
+		t.Error("empty batch inserted")
+	}
+	rows := []*domain.Data{
+		tick(0, 100, 10), tick(30, 101, 11), tick(60, 102, 12), tick(90, 103, 13), tick(120, 104, 14),
+	}
+	if _, err := d.InsertBatch(rows[:3]); err != nil {
+		t.Fatalf("insert batch: %v", err)
+	}
+	for _, row := range rows[3:] {
+		if _, err := d.Insert(row); err != nil {
+			t.Fatalf("insert: %v", err)
+		}
+	}
+	last, err := d.GetLast(from, to)
+	if err != nil {
+		t.Fatalf("get last: %v", err)
+	}
+	if last.Price != 104 || last.LastUpdate != base+120 || last.Provider != provider || last.Id == 0 {
+		t.Errorf("got last %+v, want price 104 at %d", last, base+120)
+	}
+
+	if _, err = d.Insert(tick(120, 200, 14)); err != nil {
+		t.Fatalf("insert the same natural key: %v", err)
+	}
+	if last, err = d.GetLast(from, to); err != nil || last.Price != 200 {
+		t.Errorf("got last %+v (%v), want the stored row updated to price 200", last, err)
+	}
+	if n := len(history(t, d, 0, 0, 0, 100)); n != 5 {
+		t.Errorf("got %d rows after inserting the same natural key, want 5", n)
+	}
+
+	inserted, err := d.InsertMissing(append(rows, tick(150, 105, 15)))
+	if err != nil {
+		t.Fatalf("insert missing: %v", err)
+	}
+	if inserted != 1 {
+		t.Errorf("got %d missing rows inserted, want 1", inserted)
+	}
+	if last, err = d.GetLast(from, to); err != nil || last.Price != 105 {
+		t.Errorf("got last %+v (%v), want price 105", last, err)
+	}
+	if h := history(t, d, base+120, base+120, 0, 100); len(h) != 1 || h[0].Price != 200 {
+		t.Errorf("got %+v, want the stored row kept by insert missing", h)
+	}
+}
+
+func history(t *testing.T, d db.Database, start, end, cursor int64, limit int) []*domain.Data {
+	h, err := d.History(from, to, start, end, cursor, limit)
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	return h
+}
+
+func testHistory(t *testing.T, d db.Database) {
+	h := history(t, d, 0, 0, 0, 100)
+	if len(h) != 6 {
+		t.Fatalf("got %d rows, want 6", len(h))
+	}
+	for i := 1; i < len(h); i++ {
+		if h[i].Id >= h[i-1].Id {
+			t.Errorf("rows are not the newest first: id %d after %d", h[i].Id, h[i-1].Id)
+		}
+	}
+	if h[0].LastUpdate != base+150 || h[0].FromSymbol != from || h[0].ToSymbol != to {
+		t.Errorf("got first row %+v, want the tick at %d", h[0], base+150)
+	}
+	page := history(t, d, 0, 0, h[1].Id, 2)
+	if len(page) != 2 || page[0].Id != h[2].Id || page[1].Id != h[3].Id {
+		t.Errorf("got page %+v, want the rows %d and %d", page, h[2].Id, h[3].Id)
+	}
+	if n := len(history(t, d, base+30, base+90, 0, 100)); n != 3 {
+		t.Errorf("got %d rows in the time range, want 3", n)
+	}
+}
+
+func since(t *testing.T, d db.Database, since, cursor int64, limit int) []*domain.Data {
+	s, err := d.Since(from, to, since, cursor, limit)
+	if err != nil {
+		t.Fatalf("since: %v", err)
+	}
+	return s
+}
+
+func testSince(t *testing.T, d db.Database) {
+	s := since(t, d, 0, 0, 100)
+	if len(s) != 6 {
+		t.Fatalf("got %d rows, want 6", len(s))
+	}
+	for i := 1; i < len(s); i++ {
+		if s[i].Id <= s[i-1].Id {
+			t.Errorf("rows are not in the insert order: id %d after %d", s[i].Id, s[i-1].Id)
+		}
+	}
+	page := since(t, d, 0, s[1].Id, 2)
+	if len(page) != 2 || page[0].Id != s[2].Id || page[1].Id != s[3].Id {
+		t.Errorf("got page %+v, want the rows %d and %d", page, s[2].Id, s[3].Id)
+	}
+	if n := len(since(t, d, time.Now().Add(time.Hour).UnixMilli(), 0, 100)); n != 0 {
+		t.Errorf("got %d rows stored in the future, want 0", n)
+	}
+	time.Sleep(2 * time.Millisecond) // so the rows above are stored before now
+	now := time.Now().UnixMilli()
+	if _, err := d.Insert(tick(150, 105, 15)); err != nil {
+		t.Fatalf("insert the same natural key: %v", err)
+	}
+	if s = since(t, d, now, 0, 100); len(s) != 1 || s[0].LastUpdate != base+150 {
+		t.Errorf("got %+v, want the updated row stored again", s)
+	}
+}
+
+func testCandles(t *testing.T, d db.Database) {
+	candles, err := d.Candles(from, to, 60, 0, 0, 10)
+	if err != nil {
+		t.Fatalf("candles: %v", err)
+	}
+	want := []domain.Candle{
+		{Time: base, Open: 100, High: 101, Low: 100, Close: 101, Volume: 11, Ticks: 2},
+		{Time: base + 60, Open: 102, High: 103, Low: 102, Close: 103, Volume: 13, Ticks: 2},
+		{Time: base + 120, Open: 200, High: 200, Low: 105, Close: 105, Volume: 15, Ticks: 2},
+	}
+	if len(candles) != len(want) {
+		t.Fatalf("got %d candles, want %d", len(candles), len(want))
+	}
+	for i := range want {
+		if *candles[i] != want[i] {
+			t.Errorf("got candle %+v, want %+v", *candles[i], want[i])
+		}
+	}
+	if candles, err = d.Candles(from, to, 60, 0, 0, 2); err != nil || len(candles) != 2 || candles[0].Time != base+60 {
+		t.Errorf("got %d candles (%v), want the last 2 in chronological order", len(candles), err)
+	}
+	if candles, err = d.Candles(from, to, 60, base+60, base+90, 10); err != nil || len(candles) != 1 {
+		t.Errorf("got %d candles (%v) in the time range, want 1", len(candles), err)
+	}
+}
+
+func testAlerts(t *testing.T, d db.Database) {
+	a := &domain.Alert{
+		From:      "dbtestf",
+		To:        "dbtestt",
+		Condition: domain.AlertAbove,
+		Value:     100,
+		Url:       "http://localhost/alert",
+		Secret:    "secret",
+		Enabled:   true,
+	}
+	id, err := d.AddAlert(a)
+	if err != nil {
+		t.Fatalf("add alert: %v", err)
+	}
+	defer func() {
+		_, _ = d.RemoveAlert(id)
+	}()
+	if got := findAlert(t, d, id); got == nil || got.From != from || got.To != to || got.Secret != a.Secret {
+		t.Errorf("got alert %+v, want upper-case symbols and the secret", got)
+	}
+	a.Id, a.Value, a.Enabled = id, 200, false
+	if _, err = d.UpdateAlert(a); err != nil {
+		t.Fatalf("update alert: %v", err)
+	}
+	if got := findAlert(t, d, id); got == nil || got.Value != 200 || got.Enabled {
+		t.Errorf("got alert %+v, want value 200 and disabled", got)
+	}
+	for attempt := 1; attempt <= 3; attempt++ {
+		if _, err = d.AddDelivery(&domain.Delivery{
+			AlertId: id, Url: a.Url, Attempt: attempt, Status: 500, CreatedAt: base,
+		}); err != nil {
+			t.Fatalf("add delivery: %v", err)
+		}
+	}
+	deliveries, err := d.Deliveries(id, 2)
+	if err != nil {
+		t.Fatalf("deliveries: %v", err)
+	}
+	if len(deliveries) != 2 || deliveries[0].Attempt != 3 || deliveries[1].Attempt != 2 {
+		t.Errorf("got %d deliveries, want the last 2 newest first", len(deliveries))
+	}
+	if _, err = d.RemoveAlert(id); err != nil {
+		t.Fatalf("remove alert: %v", err)
+	}
+	if got := findAlert(t, d, id); got != nil {
+		t.Errorf("removed alert %+v is still listed", got)
+	}
+}
+
+func findAlert(t *testing.T, d db.Database, id int64) *domain.Alert {
+	alerts, err := d.Alerts()
+	if err != nil {
+		t.Fatalf("alerts: %v", err)
+	}
+	for _, a := range alerts {
+		if a.Id == id {
+			return a
+		}
+	}
+	return nil
+}
+
+func testWebhooks(t *testing.T, d db.Database) {
+	w := &domain.Webhook{
+		Pairs:   []string{task, "BTC:USD"},
+		Url:     "http://localhost/webhook",
+		Secret:  "secret",
+		Window:  5,
+		Enabled: true,
+	}
+	id, err := d.AddWebhook(w)
+	if err != nil {
+		t.Fatalf("add webhook: %v", err)
+	}
+	defer func() {
+		_, _ = d.RemoveWebhook(id)
+	}()
+	got := findWebhook(t, d, id)
+	if got == nil || len(got.Pairs) != 2 || got.Pairs[0] != task || got.Secret != w.Secret || got.Window != 5 {
+		t.Errorf("got webhook %+v, want %+v", got, w)
+	}
+	w.Id, w.Enabled, w.Failures = id, false, 3
+	if _, err = d.UpdateWebhook(w); err != nil {
+		t.Fatalf("update webhook: %v", err)
+	}
+	if got = findWebhook(t, d, id); got == nil || got.Enabled || got.Failures != 3 {
+		t.Errorf("got webhook %+v, want disabled after 3 failures", got)
+	}
+	if _, err = d.RemoveWebhook(id); err != nil {
+		t.Fatalf("remove webhook: %v", err)
+	}
+	if got = findWebhook(t, d, id); got != nil {
+		t.Errorf("removed webhook %+v is still listed", got)
+	}
+}
+
+func findWebhook(t *testing.T, d db.Database, id int64) *domain.Webhook {
+	webhooks, err := d.Webhooks()
+	if err != nil {
+		t.Fatalf("webhooks: %v", err)
+	}
+	for _, w := range webhooks {
+		if w.Id == id {
+			return w
+		}
+	}
+	return nil
+}
+
+func testRetention(t *testing.T, d db.Database) {
+	pairs, err := d.Pairs()
+	if err != nil {
+		t.Fatalf("pairs: %v", err)
+	}
+	found := false
+	for _, p := range pairs {
+		found = found || p == task
+	}
+	if !found {
+		t.Errorf("got pairs %v, want %s in them", pairs, task)
+	}
+	// the minute before the base: open 50, low 40, high 70, close 55 and the 60 tick between them of the provider,
+	// the single tick of the other provider is its open, high, low and close
+	rows := []*domain.Data{tick(-60, 50, 5), tick(-50, 40, 5), tick(-40, 70, 5), tick(-30, 60, 5), tick(-20, 55, 6)}
+	other := tick(-35, 65, 7)
+	other.Provider = provider + "2"
+	if _, err = d.InsertBatch(append(rows, other)); err != nil {
+		t.Fatalf("insert batch: %v", err)
+	}
+	for _, s := range []struct {
+		before, bucket, deleted int64
+		left                    int
+	}{
+		{base, 60, 1, 11},         // the 60 tick
+		{base + 3600, 3600, 6, 5}, // all but 50, 40, 200, 105 and the tick of the other provider
+		{base + 3600, 0, 5, 0},    // all
+	} {
+		var want []*domain.Candle
+		if s.bucket > 0 {
+			want = candles(t, d, s.bucket)
+		}
+		deleted, err := d.Downsample(from, to, s.before, s.bucket)
+		if err != nil {
+			t.Fatalf("downsample: %v", err)
+		}
+		if n := len(history(t, d, 0, 0, 0, 100)); deleted != s.deleted || n != s.left {
+			t.Errorf("got %d deleted and %d rows left by %d bucket, want %d and %d", deleted, n, s.bucket, s.deleted,
+				s.left)
+		}
+		if s.bucket == 0 {
+			continue
+		}
+		got := candles(t, d, s.bucket)
+		if len(got) != len(want) || len(want) == 0 {
+			t.Fatalf("got %d candles, want %d", len(got), len(want))
+		}
+		for i := range want {
+			if got[i].Ticks, want[i].Ticks = 0, 0; *got[i] != *want[i] {
+				t.Errorf("got candle %+v, want %+v kept by downsample", got[i], want[i])
+			}
+		}
+	}
+}
+
+func candles(t *testing.T, d db.Database, interval int64) []*domain.Candle {
+	c, err := d.Candles(from, to, interval, 0, 0, 100)
+	if err != nil {
+		t.Fatalf("candles: %v", err)
+	}
+	return c
+}
@@ -0,0 +1,61 @@
+package kafka
+
+import (
+	"context"
+	"log"
+	"time"
+
+	kg "github.com/segmentio/kafka-go"
+)
+
+const (
+	Kafka         = "kafka"
+	DefaultBroker = "localhost:9092"
+	DefaultTopic  = "ccd.data"
+	batchTimeout  = 100 * time.Millisecond
+)
+
+// Sink publishes data to the kafka topic, messages are keyed by the currencies pair, so the data of the pair is
+// always in the same partition
+type Sink struct {
+	w *kg.Writer
+}
+
+// NewSink init asynchronous writer to the topic, empty brokers and topic mean the default ones
+func NewSink(brokers []string, topic string, l *log.Logger) *Sink {
+	if len(brokers) == 0 {
+		brokers = []string{DefaultBroker}
+	}
+	if topic == "" {
+		topic = DefaultTopic
+	}
+	return &Sink{
+		w: &kg.Writer{
+			Addr:                   kg.TCP(brokers...),
+			Topic:                  topic,
+			Balancer:               &kg.Hash{},
+			BatchTimeout:           batchTimeout,
+			RequiredAcks:           kg.RequireOne,
+			Async:                  true,
+			AllowAutoTopicCreation: true,
+			Completion: func(messages []kg.Message, err error) {
+				if err != nil {
+					l.Printf("kafka: failed to write %d messages: %v", len(messages), err)
+				}
+			},
+		},
+	}
+}
+
+// Publish data with the key, the message is batched by the writer and sent asynchronously
+func (s *Sink) Publish(key string, data []byte) error {
+	return s.w.WriteMessages(context.Background(), kg.Message{
+		Key:   []byte(key),
+		Value: data,
+	})
+}
+
+// Close the writer after the batched messages are sent
+func (s *Sink) Close() error {
+	return s.w.Close()
+}
@@ -0,0 +1,116 @@
+package kafka
+
+import (
+	"context"
+	"io"
+	"log"
+	"net"
+	"sync"
+	"testing"
+
+	kg "github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/protocol"
+	"github.com/segmentio/kafka-go/protocol/metadata"
+	"github.com/segmentio/kafka-go/protocol/produce"
+)
+
+const partitions = 4
+
+type record struct {
+	partition  int32
+	key, value string
+}
+
+// broker is the in-memory kafka broker with the single topic, it answers the metadata and produce requests of the
+// writer and keeps the produced records
+type broker struct {
+	topic   string
+	records []record
+	mu      sync.Mutex
+}
+
+func (b *broker) RoundTrip(_ context.Context, _ net.Addr, req kg.Request) (kg.Response, error) {
+	switch r := req.(type) {
+	case *metadata.Request:
+		res := &metadata.Response{
+			Brokers: []metadata.ResponseBroker{{NodeID: 1, Host: "localhost", Port: 9092}},
+			Topics:  []metadata.ResponseTopic{{Name: b.topic}},
+		}
+		for i := int32(0); i < partitions; i++ {
+			res.Topics[0].Partitions = append(res.Topics[0].Partitions, metadata.ResponsePartition{
+				PartitionIndex: i,
+				LeaderID:       1,
+			})
+		}
+		return res, nil
+	case *produce.Request:
+		res := &produce.Response{}
+		for _, t := range r.Topics {
+			rt := produce.ResponseTopic{Topic: t.Topic}
+			for _, p := range t.Partitions {
+				if err := b.store(p.Partition, p.RecordSet.Records); err != nil {
+					return nil, err
+				}
+				rt.Partitions = append(rt.Partitions, produce.ResponsePartition{Partition: p.Partition})
+			}
+			res.Topics = append(res.Topics, rt)
+		}
+		return res, nil
+	}
+	return nil, protocol.ErrNoRecord
+}
+
+func (b *broker) store(partition int32, records protocol.RecordReader) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for {
+		r, err := records.ReadRecord()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		key, err := protocol.ReadAll(r.Key)
+		if err != nil {
+			return err
+		}
+		value, err := protocol.ReadAll(r.Value)
+		if err != nil {
+			return err
+		}
+		b.records = append(b.records, record{partition: partition, key: string(key), value: string(value)})
+	}
+}
+
+func TestPublish(t *testing.T) {
+	s := NewSink(nil, "", log.New(io.Discard, "", 0))
+	b := &broker{topic: DefaultTopic}
+	s.w.Transport = b
+
+	for _, key := range []string{"BTC:USD", "ETH:USD", "BTC:USD"} {
+		if err := s.Publish(key, []byte(key)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the batched messages are sent before the writer is closed
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.records) != 3 {
+		t.Fatalf("got %d records, want 3", len(b.records))
+	}
+	partition := map[string]int32{}
+	for _, r := range b.records {
+		if r.key != r.value {
+			t.Errorf("got record %q with the key %q", r.value, r.key)
+		}
+		if p, ok := partition[r.key]; ok && p != r.partition {
+			t.Errorf("the records of %s are in the partitions %d and %d", r.key, p, r.partition)
+		}
+		partition[r.key] = r.partition
+	}
+}
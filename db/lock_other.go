@@ -0,0 +1,10 @@
+//go:build !unix
+
+package db
+
+import "os"
+
+// lockFile is a no-op on the platforms without flock
+func lockFile(_ *os.File) error {
+	return nil
+}
@@ -0,0 +1,14 @@
+//go:build unix
+
+package db
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile with the exclusive advisory lock, it fails at once if the file is locked by another process. The lock is
+// released when the file is closed.
+func lockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+}
@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/streamdp/ccd/domain"
+)
+
+func (d *Db) Alerts() (alerts []*domain.Alert, err error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for _, a := range d.alerts {
+		c := *a
+		alerts = append(alerts, &c)
+	}
+	return alerts, nil
+}
+
+func (d *Db) AddAlert(a *domain.Alert) (id int64, err error) {
+	if a == nil {
+		return 0, errors.New("cant insert empty alert")
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	c := *a
+	c.Id, c.From, c.To = d.nextId(), strings.ToUpper(a.From), strings.ToUpper(a.To)
+	d.alerts = append(d.alerts, &c)
+	return c.Id, nil
+}
+
+func (d *Db) UpdateAlert(a *domain.Alert) (sql.Result, error) {
+	if a == nil {
+		return nil, errors.New("empty alert")
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i := range d.alerts {
+		if d.alerts[i].Id == a.Id {
+			c := *a
+			c.From, c.To = strings.ToUpper(a.From), strings.ToUpper(a.To)
+			d.alerts[i] = &c
+			return result{affected: 1}, nil
+		}
+	}
+	return result{}, nil
+}
+
+func (d *Db) RemoveAlert(id int64) (sql.Result, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i := range d.alerts {
+		if d.alerts[i].Id == id {
+			d.alerts = append(d.alerts[:i], d.alerts[i+1:]...)
+			return result{affected: 1}, nil
+		}
+	}
+	return result{}, nil
+}
+
+func (d *Db) AddDelivery(l *domain.Delivery) (sql.Result, error) {
+	if l == nil {
+		return nil, errors.New("cant insert empty delivery")
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	c := *l
+	c.Id = d.nextId()
+	d.deliveries = append(d.deliveries, &c)
+	return result{id: c.Id, affected: 1}, nil
+}
+
+// Deliveries log of the selected alert, newest first
+func (d *Db) Deliveries(alertId int64, limit int) (result []*domain.Delivery, err error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for i := len(d.deliveries) - 1; i >= 0 && len(result) < limit; i-- {
+		if d.deliveries[i].AlertId == alertId {
+			c := *d.deliveries[i]
+			result = append(result, &c)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/streamdp/ccd/domain"
+)
+
+// Candles for the selected currencies pair aggregated over the interval (in seconds) buckets in the [start,end]
+// time range, the last limit candles are returned in chronological order
+func (d *Db) Candles(from, to string, interval, start, end int64, limit int) (result []*domain.Candle, err error) {
+	if interval <= 0 {
+		return nil, nil
+	}
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	var (
+		candles = map[int64]*domain.Candle{}
+		first   = map[int64]int64{} // ts of the open of the bucket
+		last    = map[int64]int64{} // ts of the close of the bucket
+		buckets []int64
+	)
+	for _, row := range d.data[domain.PairName(from, to)] {
+		ts := row.UpdatedAt().Unix()
+		if (start != 0 && ts < start) || (end != 0 && ts > end) {
+			continue
+		}
+		bucket := ts / interval * interval
+		c, ok := candles[bucket]
+		if !ok {
+			c = &domain.Candle{Time: bucket, Open: row.Price, High: row.Price, Low: row.Price}
+			candles[bucket], first[bucket], last[bucket] = c, ts, ts
+			buckets = append(buckets, bucket)
+		}
+		// rows are kept in the insert order, so the later row with the same ts wins the close like the greater id
+		if ts < first[bucket] {
+			c.Open, first[bucket] = row.Price, ts
+		}
+		if ts >= last[bucket] {
+			c.Close, c.Volume, last[bucket] = row.Price, row.Volume24Hour, ts
+		}
+		if row.Price > c.High {
+			c.High = row.Price
+		}
+		if row.Price < c.Low {
+			c.Low = row.Price
+		}
+		c.Ticks++
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i] < buckets[j] })
+	if len(buckets) > limit {
+		buckets = buckets[len(buckets)-limit:]
+	}
+	for _, bucket := range buckets {
+		result = append(result, candles[bucket])
+	}
+	return result, nil
+}
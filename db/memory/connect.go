@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"sync"
+
+	"github.com/streamdp/ccd/domain"
+)
+
+const Memory = "memory"
+
+// Db keeps all the data in memory, it is lost on restart, so it fits the tests and the ephemeral runs
+type Db struct {
+	data       map[string][]*domain.Data // rows of the currencies pairs in the insert order
+	keys       map[key]*domain.Data      // rows by the natural key
+	stored     map[*domain.Data]int64    // insert time of the rows in unix milliseconds
+	symbols    []*domain.Symbol
+	session    map[string]int64
+	alerts     []*domain.Alert
+	deliveries []*domain.Delivery
+	webhooks   []*domain.Webhook
+	lastId     int64
+	pipe       chan *domain.Data
+	mu         sync.RWMutex
+}
+
+// key natural key of the data row
+type key struct {
+	from, to, provider string
+	lastUpdate         int64
+}
+
+// seed symbols, the same the database migrations insert
+var seed = []*domain.Symbol{
+	{Symbol: "USDT", Unicode: '₮'},
+	{Symbol: "BTC", Unicode: '₿'},
+	{Symbol: "ETH", Unicode: '⟠'},
+	{Symbol: "USD", Unicode: '$'},
+	{Symbol: "XRP", Unicode: '✕'},
+	{Symbol: "LTC", Unicode: 'Ł'},
+	{Symbol: "EUR", Unicode: '€'},
+	{Symbol: "GBP", Unicode: '£'},
+	{Symbol: "JPY", Unicode: '¥'},
+}
+
+// New empty Db with the seed symbols
+func New() *Db {
+	d := &Db{
+		data:    map[string][]*domain.Data{},
+		keys:    map[key]*domain.Data{},
+		stored:  map[*domain.Data]int64{},
+		session: map[string]int64{},
+		pipe:    make(chan *domain.Data, 1000),
+	}
+	for _, s := range seed {
+		d.symbols = append(d.symbols, &domain.Symbol{Symbol: s.Symbol, Unicode: s.Unicode})
+	}
+	return d
+}
+
+func (d *Db) DataPipe() chan *domain.Data {
+	return d.pipe
+}
+
+// Close Db
+func (d *Db) Close() (err error) {
+	close(d.pipe)
+	return nil
+}
+
+// nextId returns the next row id, ids are unique across all the tables like the sequences of the sql databases
+func (d *Db) nextId() int64 {
+	d.lastId++
+	return d.lastId
+}
+
+// result of the change implements the sql.Result
+type result struct {
+	id       int64
+	affected int64
+}
+
+func (r result) LastInsertId() (int64, error) {
+	return r.id, nil
+}
+
+func (r result) RowsAffected() (int64, error) {
+	return r.affected, nil
+}
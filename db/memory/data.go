@@ -0,0 +1,161 @@
+package memory
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/streamdp/ccd/domain"
+)
+
+// GetLast row with the most recent data for the selected currencies pair
+func (d *Db) GetLast(from string, to string) (result *domain.Data, err error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for _, row := range d.data[domain.PairName(from, to)] {
+		if result == nil || row.LastUpdate >= result.LastUpdate {
+			result = row
+		}
+	}
+	if result == nil {
+		return nil, sql.ErrNoRows
+	}
+	return copyRow(result, from, to), nil
+}
+
+// History rows for the selected currencies pair in the [start,end] time range, newest first. The cursor is the id
+// of the last row of the previous page, zero values of start, end and cursor mean no limit.
+func (d *Db) History(from, to string, start, end, cursor int64, limit int) (result []*domain.Data, err error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	rows := d.data[domain.PairName(from, to)]
+	for i := len(rows) - 1; i >= 0 && len(result) < limit; i-- {
+		row, ts := rows[i], rows[i].UpdatedAt().Unix()
+		if (start != 0 && ts < start) || (end != 0 && ts > end) || (cursor != 0 && row.Id >= cursor) {
+			continue
+		}
+		result = append(result, copyRow(row, from, to))
+	}
+	return result, nil
+}
+
+// Since rows of the selected currencies pair stored since the time (unix milliseconds) in the insert order. The
+// cursor is the id of the last row of the previous page, zero cursor means no limit.
+func (d *Db) Since(from, to string, since, cursor int64, limit int) (result []*domain.Data, err error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for _, row := range d.data[domain.PairName(from, to)] {
+		if len(result) == limit {
+			break
+		}
+		if d.stored[row] < since || row.Id <= cursor {
+			continue
+		}
+		result = append(result, copyRow(row, from, to))
+	}
+	return result, nil
+}
+
+// Insert clients.Data from the clients.DataPipe to the Db, the stored row with the same natural key is updated
+func (d *Db) Insert(data *domain.Data) (result sql.Result, err error) {
+	if data == nil {
+		return nil, errors.New("cant insert empty data")
+	}
+	return d.InsertBatch([]*domain.Data{data})
+}
+
+// InsertBatch of the data at once, the stored rows with the same natural key are updated
+func (d *Db) InsertBatch(data []*domain.Data) (sql.Result, error) {
+	if len(data) == 0 {
+		return nil, errors.New("cant insert empty batch")
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.checkSymbols(data); err != nil {
+		return nil, err
+	}
+	var (
+		r   = result{}
+		now = time.Now().UnixMilli()
+	)
+	for _, row := range data {
+		if stored, ok := d.keys[naturalKey(row)]; ok {
+			id := stored.Id
+			*stored = *copyRow(row, stored.FromSymbol, stored.ToSymbol)
+			stored.Id = id
+			d.stored[stored] = now
+			r.affected++
+			continue
+		}
+		r.id = d.insert(row, now)
+		r.affected++
+	}
+	return r, nil
+}
+
+// InsertMissing rows that are not stored yet, the rows with the same natural key are skipped, so the same rows could
+// be inserted again safely
+func (d *Db) InsertMissing(data []*domain.Data) (inserted int64, err error) {
+	if len(data) == 0 {
+		return 0, errors.New("cant insert empty batch")
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err = d.checkSymbols(data); err != nil {
+		return 0, err
+	}
+	now := time.Now().UnixMilli()
+	for _, row := range data {
+		if _, ok := d.keys[naturalKey(row)]; ok {
+			continue
+		}
+		d.insert(row, now)
+		inserted++
+	}
+	return inserted, nil
+}
+
+// insert copy of the row stored at the time (unix milliseconds), the caller must hold the lock
+func (d *Db) insert(row *domain.Data, now int64) int64 {
+	stored := copyRow(row, strings.ToUpper(row.FromSymbol), strings.ToUpper(row.ToSymbol))
+	stored.Id = d.nextId()
+	pair := domain.PairName(row.FromSymbol, row.ToSymbol)
+	d.data[pair] = append(d.data[pair], stored)
+	d.keys[naturalKey(row)] = stored
+	d.stored[stored] = now
+	return stored.Id
+}
+
+// checkSymbols of the rows are known, like the sql databases refuse rows of the unknown symbols. The whole batch is
+// refused, so the caller must hold the lock.
+func (d *Db) checkSymbols(data []*domain.Data) error {
+	for _, row := range data {
+		if row == nil {
+			return errors.New("cant insert empty data")
+		}
+		for _, s := range []string{row.FromSymbol, row.ToSymbol} {
+			if d.symbolIndex(s) < 0 {
+				return fmt.Errorf("unknown symbol %q", s)
+			}
+		}
+	}
+	return nil
+}
+
+func naturalKey(row *domain.Data) key {
+	return key{
+		from:       strings.ToUpper(row.FromSymbol),
+		to:         strings.ToUpper(row.ToSymbol),
+		provider:   row.Provider,
+		lastUpdate: row.LastUpdate,
+	}
+}
+
+// copyRow so the stored rows are never shared with the callers
+func copyRow(row *domain.Data, from, to string) *domain.Data {
+	c := *row
+	c.FromSymbol, c.ToSymbol = from, to
+	return &c
+}
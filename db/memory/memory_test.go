@@ -0,0 +1,12 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/streamdp/ccd/db/dbtest"
+	"github.com/streamdp/ccd/db/memory"
+)
+
+func TestConformance(t *testing.T) {
+	dbtest.Run(t, memory.New())
+}
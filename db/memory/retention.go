@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/streamdp/ccd/domain"
+)
+
+// Pairs of the currencies with the stored data
+func (d *Db) Pairs() (pairs []string, err error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for pair, rows := range d.data {
+		if len(rows) > 0 {
+			pairs = append(pairs, pair)
+		}
+	}
+	sort.Strings(pairs)
+	return pairs, nil
+}
+
+// Downsample the data of the selected currencies pair older than before (unix seconds), only the open, high, low and
+// close rows of every provider in every bucket (in seconds) are kept, so the candles of the bucket stay the same
+// except the number of ticks. Zero bucket deletes all the rows.
+func (d *Db) Downsample(from, to string, before, bucket int64) (deleted int64, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var (
+		pair    = domain.PairName(from, to)
+		rows    = d.data[pair]
+		buckets = map[ohlcKey]*ohlc{}
+		keep    = map[*domain.Data]struct{}{}
+	)
+	if bucket > 0 {
+		for _, row := range rows {
+			ts := row.UpdatedAt().Unix()
+			if ts >= before {
+				continue
+			}
+			k := ohlcKey{provider: row.Provider, bucket: ts / bucket}
+			b, ok := buckets[k]
+			if !ok {
+				buckets[k] = &ohlc{open: row, high: row, low: row, close: row}
+				continue
+			}
+			// rows are kept in the insert order, so the later row with the same ts wins the close like the greater id
+			if ts < b.open.UpdatedAt().Unix() {
+				b.open = row
+			}
+			if ts >= b.close.UpdatedAt().Unix() {
+				b.close = row
+			}
+			if row.Price > b.high.Price {
+				b.high = row
+			}
+			if row.Price < b.low.Price {
+				b.low = row
+			}
+		}
+		for _, b := range buckets {
+			for _, row := range []*domain.Data{b.open, b.high, b.low, b.close} {
+				keep[row] = struct{}{}
+			}
+		}
+	}
+	kept := rows[:0]
+	for _, row := range rows {
+		if _, ok := keep[row]; ok || row.UpdatedAt().Unix() >= before {
+			kept = append(kept, row)
+			continue
+		}
+		delete(d.keys, naturalKey(row))
+		delete(d.stored, row)
+		deleted++
+	}
+	for i := len(kept); i < len(rows); i++ {
+		rows[i] = nil
+	}
+	d.data[pair] = kept
+	if len(kept) == 0 {
+		delete(d.data, pair)
+	}
+	return deleted, nil
+}
+
+// ohlcKey of the bucket of the provider rows
+type ohlcKey struct {
+	provider string
+	bucket   int64
+}
+
+// ohlc rows of the bucket
+type ohlc struct {
+	open, high, low, close *domain.Data
+}
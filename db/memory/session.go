@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+)
+
+func (d *Db) AddTask(n string, i int64) (sql.Result, error) {
+	if n == "" {
+		return nil, errors.New("cant insert empty task name")
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.session[strings.ToUpper(n)]; ok {
+		return result{}, nil
+	}
+	d.session[strings.ToUpper(n)] = i
+	return result{id: d.nextId(), affected: 1}, nil
+}
+
+func (d *Db) UpdateTask(n string, i int64) (sql.Result, error) {
+	if n == "" {
+		return nil, errors.New("empty task name")
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.session[strings.ToUpper(n)]; !ok {
+		return result{}, nil
+	}
+	d.session[strings.ToUpper(n)] = i
+	return result{affected: 1}, nil
+}
+
+func (d *Db) RemoveTask(n string) (sql.Result, error) {
+	if n == "" {
+		return nil, errors.New("empty symbol")
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.session[strings.ToUpper(n)]; !ok {
+		return result{}, nil
+	}
+	delete(d.session, strings.ToUpper(n))
+	return result{affected: 1}, nil
+}
+
+func (d *Db) GetSession() (tasks map[string]int64, err error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	tasks = make(map[string]int64, len(d.session))
+	for n, i := range d.session {
+		tasks[n] = i
+	}
+	return
+}
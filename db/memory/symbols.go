@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/streamdp/ccd/domain"
+)
+
+func (d *Db) AddSymbol(s string, u string) (sql.Result, error) {
+	if s == "" {
+		return nil, errors.New("cant insert empty symbol")
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.symbolIndex(s) >= 0 {
+		return nil, fmt.Errorf("duplicate symbol %q", strings.ToUpper(s))
+	}
+	r, _ := utf8.DecodeRuneInString(strings.ToUpper(u))
+	d.symbols = append(d.symbols, &domain.Symbol{Symbol: strings.ToUpper(s), Unicode: r})
+	return result{id: d.nextId(), affected: 1}, nil
+}
+
+func (d *Db) UpdateSymbol(s, u string) (sql.Result, error) {
+	if s == "" {
+		return nil, errors.New("empty symbol")
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	i := d.symbolIndex(s)
+	if i < 0 {
+		return result{}, nil
+	}
+	d.symbols[i].Unicode, _ = utf8.DecodeRuneInString(strings.ToUpper(u))
+	return result{affected: 1}, nil
+}
+
+func (d *Db) RemoveSymbol(s string) (sql.Result, error) {
+	if s == "" {
+		return nil, errors.New("empty symbol")
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	i := d.symbolIndex(s)
+	if i < 0 {
+		return result{}, nil
+	}
+	d.symbols = append(d.symbols[:i], d.symbols[i+1:]...)
+	return result{affected: 1}, nil
+}
+
+func (d *Db) Symbols() (symbols []*domain.Symbol, err error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for _, s := range d.symbols {
+		symbols = append(symbols, &domain.Symbol{Symbol: s.Symbol, Unicode: s.Unicode})
+	}
+	return
+}
+
+// symbolIndex returns the index of the symbol or -1, the caller must hold the lock
+func (d *Db) symbolIndex(s string) int {
+	s = strings.ToUpper(s)
+	for i := range d.symbols {
+		if d.symbols[i].Symbol == s {
+			return i
+		}
+	}
+	return -1
+}
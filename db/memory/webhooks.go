@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/streamdp/ccd/domain"
+)
+
+func (d *Db) Webhooks() (webhooks []*domain.Webhook, err error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for _, w := range d.webhooks {
+		webhooks = append(webhooks, copyWebhook(w))
+	}
+	return webhooks, nil
+}
+
+func (d *Db) AddWebhook(w *domain.Webhook) (id int64, err error) {
+	if w == nil {
+		return 0, errors.New("cant insert empty webhook")
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	c := copyWebhook(w)
+	c.Id = d.nextId()
+	d.webhooks = append(d.webhooks, c)
+	return c.Id, nil
+}
+
+func (d *Db) UpdateWebhook(w *domain.Webhook) (sql.Result, error) {
+	if w == nil {
+		return nil, errors.New("empty webhook")
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i := range d.webhooks {
+		if d.webhooks[i].Id == w.Id {
+			d.webhooks[i] = copyWebhook(w)
+			return result{affected: 1}, nil
+		}
+	}
+	return result{}, nil
+}
+
+func (d *Db) RemoveWebhook(id int64) (sql.Result, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i := range d.webhooks {
+		if d.webhooks[i].Id == id {
+			d.webhooks = append(d.webhooks[:i], d.webhooks[i+1:]...)
+			return result{affected: 1}, nil
+		}
+	}
+	return result{}, nil
+}
+
+// copyWebhook with its own pairs, so the stored webhooks are never shared with the callers
+func copyWebhook(w *domain.Webhook) *domain.Webhook {
+	c := *w
+	c.Pairs = append([]string(nil), w.Pairs...)
+	return &c
+}
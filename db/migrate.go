@@ -0,0 +1,75 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/streamdp/ccd/config"
+)
+
+// Migrate modes
+const (
+	MigrateUp   = "up"
+	MigrateDown = "down"
+	MigrateNone = "none"
+)
+
+// Migrator is implemented by the databases with the embedded schema migrations
+type Migrator interface {
+	MigrateUp() (applied int, err error)
+	MigrateDown(steps int) (reverted int, err error)
+}
+
+// Timescaler is implemented by the databases that could keep the data in the timescaledb hypertable
+type Timescaler interface {
+	SetupTimescale(compressAfter, retention int) (enabled bool, err error)
+}
+
+// migrate applies the pending migrations on startup if selected by the "-migrate" flag
+func migrate(d Database, l *log.Logger) error {
+	switch config.Migrate {
+	case MigrateUp:
+		m, ok := d.(Migrator)
+		if !ok {
+			return nil
+		}
+		applied, err := m.MigrateUp()
+		if err != nil {
+			return err
+		}
+		if applied > 0 {
+			l.Printf("applied %d database migrations", applied)
+		}
+		return setupTimescale(d, l)
+	case MigrateDown, MigrateNone:
+		return nil
+	default:
+		return fmt.Errorf("unknown migrate mode %q", config.Migrate)
+	}
+}
+
+// Revert the selected number of the last applied migrations
+func Revert(d Database, steps int) (reverted int, err error) {
+	m, ok := d.(Migrator)
+	if !ok {
+		return 0, errors.New("database doesn't support migrations")
+	}
+	return m.MigrateDown(steps)
+}
+
+// setupTimescale of the database if it supports the timescaledb and it is not disabled by the "-timescale" flag
+func setupTimescale(d Database, l *log.Logger) error {
+	t, ok := d.(Timescaler)
+	if !ok || config.Timescale == "off" {
+		return nil
+	}
+	enabled, err := t.SetupTimescale(config.TsCompressAfter, config.TsRetention)
+	if err != nil {
+		return fmt.Errorf("failed to setup timescaledb: %w", err)
+	}
+	if enabled {
+		l.Println("data is stored in the timescaledb hypertable")
+	}
+	return nil
+}
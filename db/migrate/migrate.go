@@ -0,0 +1,212 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Migration of the schema, the files are named like "0001_init.up.sql" and "0001_init.down.sql"
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Dialect of the database, the version table queries use its placeholders
+type Dialect struct {
+	// CreateTable creates the version table if not exists
+	CreateTable string
+	// Insert version, name and the unix time of the applied migration
+	Insert string
+	// Delete version of the reverted migration
+	Delete string
+	// Split the migration into the statements, for drivers that can't execute several statements at once
+	Split bool
+	// Tx runs every migration in a transaction, for databases with transactional ddl
+	Tx bool
+}
+
+// Load migrations from the fs ordered by version
+func Load(fsys fs.FS) ([]*Migration, error) {
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+	versions := map[int64]*Migration{}
+	for _, f := range files {
+		name := strings.TrimSuffix(path.Base(f), ".sql")
+		var direction string
+		switch {
+		case strings.HasSuffix(name, ".up"):
+			direction, name = "up", strings.TrimSuffix(name, ".up")
+		case strings.HasSuffix(name, ".down"):
+			direction, name = "down", strings.TrimSuffix(name, ".down")
+		default:
+			return nil, fmt.Errorf("migration %s should end with .up.sql or .down.sql", f)
+		}
+		parts := strings.SplitN(name, "_", 2)
+		version, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil || len(parts) != 2 {
+			return nil, fmt.Errorf("migration %s should start with the version like 0001_name", f)
+		}
+		b, err := fs.ReadFile(fsys, f)
+		if err != nil {
+			return nil, err
+		}
+		m, ok := versions[version]
+		if !ok {
+			m = &Migration{Version: version, Name: parts[1]}
+			versions[version] = m
+		}
+		if direction == "up" {
+			m.Up = string(b)
+		} else {
+			m.Down = string(b)
+		}
+	}
+	migrations := make([]*Migration, 0, len(versions))
+	for _, m := range versions {
+		migrations = append(migrations, m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Up applies all pending migrations in order and return the number of them
+func Up(db *sql.DB, fsys fs.FS, d Dialect) (applied int, err error) {
+	migrations, versions, err := prepare(db, fsys, d)
+	if err != nil {
+		return 0, err
+	}
+	for _, m := range migrations {
+		if versions[m.Version] {
+			continue
+		}
+		if err = run(db, d, m.Up, d.Insert, m.Version, m.Name, time.Now().Unix()); err != nil {
+			return applied, fmt.Errorf("migration %04d_%s: %w", m.Version, m.Name, err)
+		}
+		applied++
+	}
+	return applied, nil
+}
+
+// Down reverts the selected number of the last applied migrations and return the number of them
+func Down(db *sql.DB, fsys fs.FS, d Dialect, steps int) (reverted int, err error) {
+	migrations, versions, err := prepare(db, fsys, d)
+	if err != nil {
+		return 0, err
+	}
+	for i := len(migrations) - 1; i >= 0 && reverted < steps; i-- {
+		m := migrations[i]
+		if !versions[m.Version] {
+			continue
+		}
+		if err = run(db, d, m.Down, d.Delete, m.Version); err != nil {
+			return reverted, fmt.Errorf("migration %04d_%s: %w", m.Version, m.Name, err)
+		}
+		reverted++
+	}
+	return reverted, nil
+}
+
+func prepare(db *sql.DB, fsys fs.FS, d Dialect) ([]*Migration, map[int64]bool, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err = db.Exec(d.CreateTable); err != nil {
+		return nil, nil, err
+	}
+	rows, err := db.Query(`select version from schema_migrations`)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	versions := map[int64]bool{}
+	for rows.Next() {
+		var v int64
+		if err = rows.Scan(&v); err != nil {
+			return nil, nil, err
+		}
+		versions[v] = true
+	}
+	return migrations, versions, rows.Err()
+}
+
+// execer is implemented by both *sql.Conn and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// run the migration sql and the version table query, the statements share one connection, so the session variables
+// set by the migration survive between them
+func run(db *sql.DB, d Dialect, migration, query string, args ...interface{}) (err error) {
+	ctx := context.Background()
+	var e execer
+	if d.Tx {
+		var tx *sql.Tx
+		if tx, err = db.BeginTx(ctx, nil); err != nil {
+			return err
+		}
+		defer func() {
+			if err != nil {
+				_ = tx.Rollback()
+				return
+			}
+			err = tx.Commit()
+		}()
+		e = tx
+	} else {
+		var conn *sql.Conn
+		if conn, err = db.Conn(ctx); err != nil {
+			return err
+		}
+		defer func() {
+			_ = conn.Close()
+		}()
+		e = conn
+	}
+	statements := []string{migration}
+	if d.Split {
+		statements = split(migration)
+	}
+	for _, s := range statements {
+		if strings.TrimSpace(s) == "" {
+			continue
+		}
+		if _, err = e.ExecContext(ctx, s); err != nil {
+			return err
+		}
+	}
+	_, err = e.ExecContext(ctx, query, args...)
+	return err
+}
+
+// split the sql into the statements ended with a semicolon at the end of the line
+func split(migration string) (statements []string) {
+	var b strings.Builder
+	for _, line := range strings.Split(migration, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "--") {
+			continue
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+		if strings.HasSuffix(strings.TrimSpace(line), ";") {
+			statements = append(statements, b.String())
+			b.Reset()
+		}
+	}
+	return append(statements, b.String())
+}
@@ -0,0 +1,95 @@
+package mysql
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/streamdp/ccd/domain"
+)
+
+func (d *Db) Alerts() (alerts []*domain.Alert, err error) {
+	rows, err := d.Query(
+		"select _id, fsym, tsym, `condition`, value, window_sec, url, secret, enabled from alerts order by _id",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	for rows.Next() {
+		a := &domain.Alert{}
+		if err = rows.Scan(
+			&a.Id, &a.From, &a.To, &a.Condition, &a.Value, &a.Window, &a.Url, &a.Secret, &a.Enabled,
+		); err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, a)
+	}
+	return alerts, rows.Err()
+}
+
+func (d *Db) AddAlert(a *domain.Alert) (id int64, err error) {
+	if a == nil {
+		return 0, errors.New("cant insert empty alert")
+	}
+	result, err := d.Exec(
+		"insert into alerts (fsym, tsym, `condition`, value, window_sec, url, secret, enabled) "+
+			"values (?,?,?,?,?,?,?,?);",
+		strings.ToUpper(a.From), strings.ToUpper(a.To), a.Condition, a.Value, a.Window, a.Url, a.Secret, a.Enabled,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (d *Db) UpdateAlert(a *domain.Alert) (result sql.Result, err error) {
+	if a == nil {
+		return nil, errors.New("empty alert")
+	}
+	return d.Exec(
+		"update alerts set fsym=?, tsym=?, `condition`=?, value=?, window_sec=?, url=?, secret=?, enabled=? "+
+			"where _id=?;",
+		strings.ToUpper(a.From), strings.ToUpper(a.To), a.Condition, a.Value, a.Window, a.Url, a.Secret, a.Enabled,
+		a.Id,
+	)
+}
+
+func (d *Db) RemoveAlert(id int64) (result sql.Result, err error) {
+	return d.Exec(`delete from alerts where _id=?;`, id)
+}
+
+func (d *Db) AddDelivery(l *domain.Delivery) (result sql.Result, err error) {
+	if l == nil {
+		return nil, errors.New("cant insert empty delivery")
+	}
+	return d.Exec(
+		`insert into deliveries (alert_id, url, attempt, status, error, created_at) values (?,?,?,?,?,?);`,
+		l.AlertId, l.Url, l.Attempt, l.Status, l.Error, l.CreatedAt,
+	)
+}
+
+// Deliveries log of the selected alert, newest first
+func (d *Db) Deliveries(alertId int64, limit int) (result []*domain.Delivery, err error) {
+	rows, err := d.Query(
+		`select _id, alert_id, url, attempt, status, error, created_at from deliveries
+			where alert_id=? order by _id desc limit ?;`,
+		alertId, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	for rows.Next() {
+		l := &domain.Delivery{}
+		if err = rows.Scan(&l.Id, &l.AlertId, &l.Url, &l.Attempt, &l.Status, &l.Error, &l.CreatedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, l)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,49 @@
+package mysql
+
+import (
+	"github.com/streamdp/ccd/domain"
+)
+
+// Candles for the selected currencies pair aggregated over the interval (in seconds) buckets in the [start,end]
+// time range, the last limit candles are returned in chronological order
+func (d *Db) Candles(from, to string, interval, start, end int64, limit int) (result []*domain.Candle, err error) {
+	query := `
+		select bucket, open, high, low, close, volume, ticks
+		from (
+		    select
+		        ts div ? * ? as bucket,
+		        substring_index(group_concat(price order by ts, _id), ',', 1) as open,
+		        max(price) as high,
+		        min(price) as low,
+		        substring_index(group_concat(price order by ts desc, _id desc), ',', 1) as close,
+		        substring_index(group_concat(volume24hour order by ts desc, _id desc), ',', 1) as volume,
+		        count(*) as ticks
+		    from (
+		        select _id, price, volume24hour, ` + lastUpdateUnix + ` as ts
+		        from data
+		        where fromSym=(select _id from symbols where symbol=?)
+		          and toSym=(select _id from symbols where symbol=?)
+		    ) as d
+		    where (? = 0 or ts >= ?)
+		      and (? = 0 or ts <= ?)
+		    group by bucket
+		    ORDER BY bucket DESC limit ?
+		) as c
+		ORDER BY bucket;
+`
+	rows, err := d.Query(query, interval, interval, from, to, start, start, end, end, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	for rows.Next() {
+		c := &domain.Candle{}
+		if err = rows.Scan(&c.Time, &c.Open, &c.High, &c.Low, &c.Close, &c.Volume, &c.Ticks); err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+	}
+	return result, rows.Err()
+}
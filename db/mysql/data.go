@@ -2,7 +2,10 @@ package mysql
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/streamdp/ccd/domain"
 )
@@ -26,11 +29,12 @@ func (d *Db) GetLast(from string, to string) (result *domain.Data, err error) {
 		    supply,
 		    mktcap,
 		    lastupdate, 
-		    displaydataraw 
+		    displaydataraw,
+		    provider
 		from data 
 		where fromSym=(select _id from symbols where symbol=?) 
 		  and toSym=(select _id from symbols where symbol=?) 
-		ORDER BY lastupdate DESC limit 1;
+		ORDER BY ` + lastUpdateMilli + ` DESC, _id DESC limit 1;
 `
 	if err = d.QueryRow(query, from, to).Scan(
 		&result.Id,
@@ -45,55 +49,275 @@ func (d *Db) GetLast(from string, to string) (result *domain.Data, err error) {
 		&result.MktCap,
 		&result.LastUpdate,
 		&result.DisplayDataRaw,
+		&result.Provider,
 	); err != nil {
 		return nil, err
 	}
 	return result, nil
 }
 
-// Insert clients.Data from the clients.DataPipe to the Db
-func (d *Db) Insert(data *domain.Data) (result sql.Result, err error) {
-	if data == nil {
-		return nil, errors.New("cant insert empty data")
+// lastUpdateMilli normalizes lastupdate to unix milliseconds, so the rows of the providers sending seconds and
+// milliseconds are ordered by time
+const lastUpdateMilli = `(case when cast(lastupdate as signed) > 9999999999 then cast(lastupdate as signed) ` +
+	`else cast(lastupdate as signed) * 1000 end)`
+
+// lastUpdateUnix normalizes lastupdate to unix seconds, because some providers (e.g. huobi) send milliseconds
+const lastUpdateUnix = `(case when cast(lastupdate as signed) > 9999999999 then cast(lastupdate as signed) div 1000 ` +
+	`else cast(lastupdate as signed) end)`
+
+// History rows for the selected currencies pair in the [start,end] time range, newest first. The cursor is the id
+// of the last row of the previous page, zero values of start, end and cursor mean no limit.
+func (d *Db) History(from, to string, start, end, cursor int64, limit int) (result []*domain.Data, err error) {
+	query := `
+		select
+		    _id,
+		    change24hour,
+		    changepct24hour,
+		    open24hour, 
+		    volume24hour,
+		    low24hour, 
+		    high24hour, 
+		    price,
+		    supply,
+		    mktcap,
+		    lastupdate, 
+		    displaydataraw,
+		    provider
+		from data 
+		where fromSym=(select _id from symbols where symbol=?) 
+		  and toSym=(select _id from symbols where symbol=?) 
+		  and (? = 0 or ` + lastUpdateUnix + ` >= ?)
+		  and (? = 0 or ` + lastUpdateUnix + ` <= ?)
+		  and (? = 0 or _id < ?)
+		ORDER BY _id DESC limit ?;
+`
+	rows, err := d.Query(query, from, to, start, start, end, end, cursor, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	for rows.Next() {
+		data := &domain.Data{
+			FromSymbol: from,
+			ToSymbol:   to,
+		}
+		if err = rows.Scan(
+			&data.Id,
+			&data.Change24Hour,
+			&data.ChangePct24Hour,
+			&data.Open24Hour,
+			&data.Volume24Hour,
+			&data.Low24Hour,
+			&data.High24Hour,
+			&data.Price,
+			&data.Supply,
+			&data.MktCap,
+			&data.LastUpdate,
+			&data.DisplayDataRaw,
+			&data.Provider,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, data)
 	}
+	return result, rows.Err()
+}
+
+// Since rows of the selected currencies pair stored since the time (unix milliseconds) in the insert order. The
+// cursor is the id of the last row of the previous page, zero cursor means no limit.
+func (d *Db) Since(from, to string, since, cursor int64, limit int) (result []*domain.Data, err error) {
+	query := `
+		select
+		    _id,
+		    change24hour,
+		    changepct24hour,
+		    open24hour, 
+		    volume24hour,
+		    low24hour, 
+		    high24hour, 
+		    price,
+		    supply,
+		    mktcap,
+		    lastupdate, 
+		    displaydataraw,
+		    provider
+		from data 
+		where fromSym=(select _id from symbols where symbol=?) 
+		  and toSym=(select _id from symbols where symbol=?) 
+		  and stored >= ?
+		  and _id > ?
+		ORDER BY _id limit ?;
+`
+	rows, err := d.Query(query, from, to, since, cursor, limit)
 	if err != nil {
 		return nil, err
 	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	for rows.Next() {
+		data := &domain.Data{
+			FromSymbol: from,
+			ToSymbol:   to,
+		}
+		if err = rows.Scan(
+			&data.Id,
+			&data.Change24Hour,
+			&data.ChangePct24Hour,
+			&data.Open24Hour,
+			&data.Volume24Hour,
+			&data.Low24Hour,
+			&data.High24Hour,
+			&data.Price,
+			&data.Supply,
+			&data.MktCap,
+			&data.LastUpdate,
+			&data.DisplayDataRaw,
+			&data.Provider,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, data)
+	}
+	return result, rows.Err()
+}
+
+// upsert updates the stored row with the same natural key (currencies pair, last update and provider) instead of
+// inserting the duplicate
+const upsert = ` on duplicate key update
+		change24hour=values(change24hour),
+		changepct24hour=values(changepct24hour),
+		open24hour=values(open24hour),
+		volume24hour=values(volume24hour),
+		low24hour=values(low24hour),
+		high24hour=values(high24hour),
+		price=values(price),
+		supply=values(supply),
+		mktcap=values(mktcap),
+		displaydataraw=values(displaydataraw),
+		stored=values(stored)`
+
+// skipExisting keeps the stored row with the same natural key
+const skipExisting = ` on duplicate key update _id=_id`
+
+// Insert clients.Data from the clients.DataPipe to the Db, the stored row with the same natural key is updated
+func (d *Db) Insert(data *domain.Data) (result sql.Result, err error) {
+	if data == nil {
+		return nil, errors.New("cant insert empty data")
+	}
 	query := `insert into data (
-		                  fromSym,
-		                  toSym,
-		                  change24hour,
-		                  changepct24hour,
-		                  open24hour,
-		                  volume24hour,
-		                  low24hour,
-		                  high24hour,
-		                  price, 
-		                  supply, 
-		                  mktcap,
-		                  lastupdate,
-		                  displaydataraw
-		) 
+                  fromSym,
+                  toSym,
+                  change24hour,
+                  changepct24hour,
+                  open24hour,
+                  volume24hour,
+                  low24hour,
+                  high24hour,
+                  price,
+                  supply,
+                  mktcap,
+                  lastupdate,
+                  displaydataraw,
+                  provider,
+                  stored
+        )
 		values (
 		        (SELECT _id FROM symbols WHERE symbol=?),
 		        (SELECT _id FROM symbols WHERE symbol=?),
-		        ?,?,?,?,?,?,?,?,?,?,?
-		)
-`
-	return d.Exec(
-		query,
-		&data.FromSymbol,
-		&data.ToSymbol,
-		&data.Change24Hour,
-		&data.ChangePct24Hour,
-		&data.Open24Hour,
-		&data.Volume24Hour,
-		&data.Low24Hour,
-		&data.High24Hour,
-		&data.Price,
-		&data.Supply,
-		&data.MktCap,
-		&data.LastUpdate,
-		&data.DisplayDataRaw,
+		        ?,?,?,?,?,?,?,?,?,?,?,?,?
+		)` + upsert
+	return d.Exec(query, rowArgs(data, time.Now().UnixMilli())...)
+}
+
+// insertColumns number of the columns of the inserted row
+const insertColumns = 15
+
+// InsertBatch of the data with one multi-row insert, the stored rows with the same natural key are updated
+func (d *Db) InsertBatch(data []*domain.Data) (result sql.Result, err error) {
+	return d.insertBatch(data, upsert)
+}
+
+// InsertMissing rows that are not stored yet, the rows with the same natural key are skipped, so the same rows could
+// be inserted again safely
+func (d *Db) InsertMissing(data []*domain.Data) (inserted int64, err error) {
+	result, err := d.insertBatch(data, skipExisting)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// maxBatchRows is the max number of the rows inserted with one statement, mysql allows 65535 placeholders in the
+// prepared statement
+const maxBatchRows = 65535 / insertColumns
+
+// insertBatch of the data, the large batches are split into several multi-row inserts to keep the number of the
+// statement parameters under the limit
+func (d *Db) insertBatch(data []*domain.Data, onConflict string) (result sql.Result, err error) {
+	if len(data) == 0 {
+		return nil, errors.New("cant insert empty batch")
+	}
+	var affected int64
+	for len(data) > 0 {
+		n := len(data)
+		if n > maxBatchRows {
+			n = maxBatchRows
+		}
+		if result, err = d.insertRows(data[:n], onConflict); err != nil {
+			return nil, err
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		affected += rows
+		data = data[n:]
+	}
+	return driver.RowsAffected(affected), nil
+}
+
+// insertRows with one multi-row insert
+func (d *Db) insertRows(data []*domain.Data, onConflict string) (result sql.Result, err error) {
+	var (
+		b      strings.Builder
+		args   = make([]interface{}, 0, len(data)*insertColumns)
+		stored = time.Now().UnixMilli()
 	)
+	b.WriteString(`insert into data (fromSym, toSym, change24hour, changepct24hour, open24hour, volume24hour, ` +
+		`low24hour, high24hour, price, supply, mktcap, lastupdate, displaydataraw, provider, stored) values `)
+	for i, row := range data {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString("((SELECT _id FROM symbols WHERE symbol=?),(SELECT _id FROM symbols WHERE symbol=?)")
+		b.WriteString(strings.Repeat(",?", insertColumns-2))
+		b.WriteString(")")
+		args = append(args, rowArgs(row, stored)...)
+	}
+	b.WriteString(onConflict)
+	return d.Exec(b.String(), args...)
+}
+
+// rowArgs of the inserted row, stored is the insert time in unix milliseconds
+func rowArgs(row *domain.Data, stored int64) []interface{} {
+	return []interface{}{
+		row.FromSymbol,
+		row.ToSymbol,
+		row.Change24Hour,
+		row.ChangePct24Hour,
+		row.Open24Hour,
+		row.Volume24Hour,
+		row.Low24Hour,
+		row.High24Hour,
+		row.Price,
+		row.Supply,
+		row.MktCap,
+		row.LastUpdate,
+		row.DisplayDataRaw,
+		row.Provider,
+		stored,
+	}
 }
@@ -0,0 +1,35 @@
+-- upgrade the data table created by model/init_mysql/init.sql before the migrations were introduced, the databases
+-- created by the migrations are skipped. Mysql has no conditional ddl, so the statements are prepared from the checks
+-- of the information schema, the migration statements share one connection to keep the session variables.
+set @baseline = (select count(*) from information_schema.tables
+                 where table_schema = database() and table_name = 'data') > 0
+    and (select count(*) from schema_migrations where version = 1) = 0;
+
+set @query = if(@baseline and (select count(*) from information_schema.columns
+                               where table_schema = database() and table_name = 'data'
+                                 and column_name = 'provider') = 0,
+                'alter table data add column provider varchar(32) default '''' not null',
+                'do 0');
+prepare stmt from @query;
+execute stmt;
+deallocate prepare stmt;
+
+set @query = if(@baseline,
+                'delete a from data a join data b
+                 on a._id < b._id and a.fromSym = b.fromSym and a.toSym = b.toSym
+                   and a.lastupdate = b.lastupdate and a.provider = b.provider',
+                'do 0');
+prepare stmt from @query;
+execute stmt;
+deallocate prepare stmt;
+
+-- the mediumtext lastupdate can't be a part of the unique key
+set @query = if(@baseline and (select count(*) from information_schema.statistics
+                               where table_schema = database() and table_name = 'data'
+                                 and index_name = 'data_natural_key_uindex') = 0,
+                'alter table data modify lastupdate varchar(32) not null,
+                 add constraint data_natural_key_uindex unique (fromSym, toSym, lastupdate, provider)',
+                'do 0');
+prepare stmt from @query;
+execute stmt;
+deallocate prepare stmt;
@@ -0,0 +1,3 @@
+drop table if exists webhooks;
+drop table if exists deliveries;
+drop table if exists alerts;
@@ -0,0 +1,35 @@
+create table if not exists alerts
+(
+    _id         int auto_increment primary key,
+    fsym        varchar(64) not null,
+    tsym        varchar(64) not null,
+    `condition` varchar(16) not null,
+    value       double      not null,
+    window_sec  bigint      default 0 not null,
+    url         text        not null,
+    secret      text        not null,
+    enabled     boolean     default true not null
+) collate = utf8_general_ci;
+
+create table if not exists deliveries
+(
+    _id        int auto_increment primary key,
+    alert_id   int     not null,
+    url        text    not null,
+    attempt    integer not null,
+    status     integer not null,
+    error      text    not null,
+    created_at bigint  not null,
+    index deliveries_alert_id_index (alert_id)
+) collate = utf8_general_ci;
+
+create table if not exists webhooks
+(
+    _id        int auto_increment primary key,
+    pairs      text    not null,
+    url        text    not null,
+    secret     text    not null,
+    window_sec bigint  default 1 not null,
+    enabled    boolean default true not null,
+    failures   integer default 0 not null
+) collate = utf8_general_ci;
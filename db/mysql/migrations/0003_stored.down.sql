@@ -0,0 +1,3 @@
+drop index data_pair_stored_index on data;
+
+alter table data drop column stored;
@@ -0,0 +1,29 @@
+package mysql_test
+
+import (
+	"testing"
+
+	"github.com/streamdp/ccd/config"
+	"github.com/streamdp/ccd/db/dbtest"
+	"github.com/streamdp/ccd/db/mysql"
+)
+
+// TestConformance runs against the database selected by the "CCDC_TEST_MYSQL" connection string, e.g.
+// "ccd:ccd@tcp(localhost:3306)/ccd_test", the migrations are applied to it
+func TestConformance(t *testing.T) {
+	dataSource := config.GetEnv("CCDC_TEST_MYSQL")
+	if dataSource == "" {
+		t.Skip("CCDC_TEST_MYSQL is not set")
+	}
+	d, err := mysql.Connect(dataSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = d.Close()
+	})
+	if _, err = d.MigrateUp(); err != nil {
+		t.Fatal(err)
+	}
+	dbtest.Run(t, d)
+}
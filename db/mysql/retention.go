@@ -0,0 +1,67 @@
+package mysql
+
+import (
+	"github.com/streamdp/ccd/domain"
+)
+
+// Pairs of the currencies with the stored data
+func (d *Db) Pairs() (pairs []string, err error) {
+	rows, err := d.Query(`
+		select f.symbol, t.symbol
+		from symbols f, symbols t
+		where exists(select 1 from data where fromSym=f._id and toSym=t._id)
+		ORDER BY f.symbol, t.symbol;
+`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	for rows.Next() {
+		var from, to string
+		if err = rows.Scan(&from, &to); err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, domain.PairName(from, to))
+	}
+	return pairs, rows.Err()
+}
+
+// Downsample the data of the selected currencies pair older than before (unix seconds), only the open, high, low and
+// close rows of every provider in every bucket (in seconds) are kept, so the candles of the bucket stay the same
+// except the number of ticks. Zero bucket deletes all the rows.
+func (d *Db) Downsample(from, to string, before, bucket int64) (deleted int64, err error) {
+	query := `
+		delete d from data d
+		join (
+		    select
+		        provider,
+		        bucket,
+		        substring_index(group_concat(_id order by ts, _id), ',', 1) as open,
+		        substring_index(group_concat(_id order by price desc, _id), ',', 1) as high,
+		        substring_index(group_concat(_id order by price, _id), ',', 1) as low,
+		        substring_index(group_concat(_id order by ts desc, _id desc), ',', 1) as close
+		    from (
+		        select _id, provider, price, ts, ts div greatest(?, 1) as bucket
+		        from (
+		            select _id, provider, price, ` + lastUpdateUnix + ` as ts
+		            from data
+		            where fromSym=(select _id from symbols where symbol=?)
+		              and toSym=(select _id from symbols where symbol=?)
+		        ) as p
+		        where ts < ?
+		    ) as b
+		    group by provider, bucket
+		) as k on k.provider = d.provider and k.bucket = ` + lastUpdateUnix + ` div greatest(?, 1)
+		where d.fromSym=(select _id from symbols where symbol=?)
+		  and d.toSym=(select _id from symbols where symbol=?)
+		  and ` + lastUpdateUnix + ` < ?
+		  and (? = 0 or d._id not in (k.open, k.high, k.low, k.close));
+`
+	result, err := d.Exec(query, bucket, from, to, before, bucket, from, to, before, bucket)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,51 @@
+package nats
+
+import (
+	"strings"
+
+	nc "github.com/nats-io/nats.go"
+)
+
+const (
+	Nats           = "nats"
+	DefaultSubject = "ccd.data"
+	// KeyHeader contains the FROM:TO currencies pair of the message
+	KeyHeader = "Key"
+)
+
+// Sink publishes data to the NATS subject of the currencies pair, e.g. "ccd.data.BTC.USD"
+type Sink struct {
+	conn    *nc.Conn
+	subject string
+}
+
+// NewSink connects to the NATS server, empty url and subject mean the default ones
+func NewSink(url, subject string) (*Sink, error) {
+	if url == "" {
+		url = nc.DefaultURL
+	}
+	if subject == "" {
+		subject = DefaultSubject
+	}
+	conn, err := nc.Connect(url, nc.MaxReconnects(-1))
+	if err != nil {
+		return nil, err
+	}
+	return &Sink{
+		conn:    conn,
+		subject: subject,
+	}, nil
+}
+
+// Publish data with the key, the message is buffered by the client and sent asynchronously
+func (s *Sink) Publish(key string, data []byte) error {
+	msg := nc.NewMsg(s.subject + "." + strings.ReplaceAll(key, ":", "."))
+	msg.Header.Set(KeyHeader, key)
+	msg.Data = data
+	return s.conn.PublishMsg(msg)
+}
+
+// Close the connection after the buffered messages are sent
+func (s *Sink) Close() error {
+	return s.conn.Drain()
+}
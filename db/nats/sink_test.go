@@ -0,0 +1,69 @@
+package nats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	nc "github.com/nats-io/nats.go"
+)
+
+// newTestServer starts the embedded NATS server on a random port
+func newTestServer(t *testing.T) *server.Server {
+	s, err := server.NewServer(&server.Options{Host: "127.0.0.1", Port: -1, NoLog: true, NoSigs: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	go s.Start()
+	if !s.ReadyForConnections(5 * time.Second) {
+		t.Fatal("nats server is not ready")
+	}
+	t.Cleanup(s.Shutdown)
+	return s
+}
+
+func TestPublish(t *testing.T) {
+	srv := newTestServer(t)
+	conn, err := nc.Connect(srv.ClientURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(conn.Close)
+	sub, err := conn.SubscribeSync(DefaultSubject + ".>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = conn.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewSink(srv.ClientURL(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = s.Publish("BTC:USD", []byte(`{"price":42}`)); err != nil {
+		t.Fatal(err)
+	}
+	// the buffered messages are sent before the connection is closed
+	if err = s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := sub.NextMsg(5 * time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Subject != "ccd.data.BTC.USD" || msg.Header.Get(KeyHeader) != "BTC:USD" ||
+		string(msg.Data) != `{"price":42}` {
+		t.Errorf("got message %q %v %q", msg.Subject, msg.Header, msg.Data)
+	}
+}
+
+func TestNewSinkError(t *testing.T) {
+	srv := newTestServer(t)
+	url := srv.ClientURL()
+	srv.Shutdown()
+	if _, err := NewSink(url, ""); err == nil {
+		t.Error("got no error connecting to the stopped server")
+	}
+}
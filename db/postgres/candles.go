@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"github.com/streamdp/ccd/domain"
+)
+
+// Candles for the selected currencies pair aggregated over the interval (in seconds) buckets in the [start,end]
+// time range, the last limit candles are returned in chronological order. The candles are rolled up from the
+// continuous aggregates when the interval is a multiple of their bucket, otherwise from the raw data.
+func (d *Db) Candles(from, to string, interval, start, end int64, limit int) (result []*domain.Candle, err error) {
+	query := `
+		select bucket, open, high, low, close, volume, ticks
+		from (
+		    select
+		        (ts / $3::bigint) * $3::bigint as bucket,
+		        (array_agg(price order by ts, _id))[1] as open,
+		        max(price) as high,
+		        min(price) as low,
+		        (array_agg(price order by ts desc, _id desc))[1] as close,
+		        (array_agg(volume24hour order by ts desc, _id desc))[1] as volume,
+		        count(*) as ticks
+		    from (
+		        select _id, price, volume24hour, extract(epoch from ts)::bigint as ts
+		        from data
+		        where fromSym=(select _id from symbols where symbol=$1)
+		          and toSym=(select _id from symbols where symbol=$2)
+		          and ($4::bigint = 0 or ts >= to_timestamp($4::bigint))
+		          and ($5::bigint = 0 or ts <= to_timestamp($5::bigint))
+		    ) as d
+		    group by bucket
+		    ORDER BY bucket DESC limit $6
+		) as c
+		ORDER BY bucket;
+`
+	if a := d.aggregateOf(interval); a != nil {
+		query = a.candles
+	}
+	rows, err := d.Query(query, from, to, interval, start, end, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	for rows.Next() {
+		c := &domain.Candle{}
+		if err = rows.Scan(&c.Time, &c.Open, &c.High, &c.Low, &c.Close, &c.Volume, &c.Ticks); err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+	}
+	return result, rows.Err()
+}
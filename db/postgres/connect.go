@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"database/sql"
+	"sync/atomic"
 
 	_ "github.com/lib/pq"
 	"github.com/streamdp/ccd/domain"
@@ -12,7 +13,8 @@ const Postgres = "postgres"
 // Db needed to add new methods for an instance *sql.Db
 type Db struct {
 	*sql.DB
-	pipe chan *domain.Data
+	pipe       chan *domain.Data
+	aggregates atomic.Int32 // availability of the timescaledb continuous aggregates
 }
 
 func (d *Db) DataPipe() chan *domain.Data {
@@ -2,7 +2,11 @@ package postgres
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/streamdp/ccd/domain"
 )
@@ -26,11 +30,12 @@ func (d *Db) GetLast(from string, to string) (result *domain.Data, err error) {
 		       supply,
 		       mktcap, 
 		       lastupdate,
-		       displaydataraw
+		       displaydataraw,
+		       provider
 		from data 
 		where fromSym=(select _id from symbols where symbol=$1)
 		  and toSym=(select _id from symbols where symbol=$2)
-		ORDER BY lastupdate DESC limit 1;
+		ORDER BY ts DESC limit 1;
 `
 	err = d.QueryRow(query, from, to).Scan(
 		&result.Id,
@@ -45,6 +50,7 @@ func (d *Db) GetLast(from string, to string) (result *domain.Data, err error) {
 		&result.MktCap,
 		&result.LastUpdate,
 		&result.DisplayDataRaw,
+		&result.Provider,
 	)
 	if err != nil {
 		return nil, err
@@ -52,14 +58,149 @@ func (d *Db) GetLast(from string, to string) (result *domain.Data, err error) {
 	return result, nil
 }
 
-// Insert clients.Data from the clients.DataPipe to the Db
-func (d *Db) Insert(data *domain.Data) (result sql.Result, err error) {
-	if data == nil {
-		return nil, errors.New("cant insert empty data")
+// History rows for the selected currencies pair in the [start,end] time range, newest first. The cursor is the id
+// of the last row of the previous page, zero values of start, end and cursor mean no limit.
+func (d *Db) History(from, to string, start, end, cursor int64, limit int) (result []*domain.Data, err error) {
+	query := `
+		select 
+		       _id,
+		       change24hour,
+		       changepct24hour,
+		       open24hour,
+		       volume24hour,
+		       low24hour,
+		       high24hour, 
+		       price, 
+		       supply,
+		       mktcap, 
+		       lastupdate,
+		       displaydataraw,
+		       provider
+		from data 
+		where fromSym=(select _id from symbols where symbol=$1)
+		  and toSym=(select _id from symbols where symbol=$2)
+		  and ($3::bigint = 0 or ts >= to_timestamp($3::bigint))
+		  and ($4::bigint = 0 or ts <= to_timestamp($4::bigint))
+		  and ($5::bigint = 0 or _id < $5::bigint)
+		ORDER BY _id DESC limit $6;
+`
+	rows, err := d.Query(query, from, to, start, end, cursor, limit)
+	if err != nil {
+		return nil, err
 	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	for rows.Next() {
+		data := &domain.Data{
+			FromSymbol: from,
+			ToSymbol:   to,
+		}
+		if err = rows.Scan(
+			&data.Id,
+			&data.Change24Hour,
+			&data.ChangePct24Hour,
+			&data.Open24Hour,
+			&data.Volume24Hour,
+			&data.Low24Hour,
+			&data.High24Hour,
+			&data.Price,
+			&data.Supply,
+			&data.MktCap,
+			&data.LastUpdate,
+			&data.DisplayDataRaw,
+			&data.Provider,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, data)
+	}
+	return result, rows.Err()
+}
+
+// Since rows of the selected currencies pair stored since the time (unix milliseconds) in the insert order. The
+// cursor is the id of the last row of the previous page, zero cursor means no limit.
+func (d *Db) Since(from, to string, since, cursor int64, limit int) (result []*domain.Data, err error) {
+	query := `
+		select 
+		       _id,
+		       change24hour,
+		       changepct24hour,
+		       open24hour,
+		       volume24hour,
+		       low24hour,
+		       high24hour, 
+		       price, 
+		       supply,
+		       mktcap, 
+		       lastupdate,
+		       displaydataraw,
+		       provider
+		from data 
+		where fromSym=(select _id from symbols where symbol=$1)
+		  and toSym=(select _id from symbols where symbol=$2)
+		  and stored >= $3
+		  and _id > $4
+		ORDER BY _id limit $5;
+`
+	rows, err := d.Query(query, from, to, since, cursor, limit)
 	if err != nil {
 		return nil, err
 	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	for rows.Next() {
+		data := &domain.Data{
+			FromSymbol: from,
+			ToSymbol:   to,
+		}
+		if err = rows.Scan(
+			&data.Id,
+			&data.Change24Hour,
+			&data.ChangePct24Hour,
+			&data.Open24Hour,
+			&data.Volume24Hour,
+			&data.Low24Hour,
+			&data.High24Hour,
+			&data.Price,
+			&data.Supply,
+			&data.MktCap,
+			&data.LastUpdate,
+			&data.DisplayDataRaw,
+			&data.Provider,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, data)
+	}
+	return result, rows.Err()
+}
+
+// upsert updates the stored row with the same natural key (currencies pair, last update and provider) instead of
+// inserting the duplicate. The key includes ts, because the unique indexes of the hypertable must include its time
+// column, ts is set from lastupdate by the trigger, so the key stays the same.
+const upsert = ` on conflict (fromSym, toSym, lastupdate, provider, ts) do update set
+		change24hour=excluded.change24hour,
+		changepct24hour=excluded.changepct24hour,
+		open24hour=excluded.open24hour,
+		volume24hour=excluded.volume24hour,
+		low24hour=excluded.low24hour,
+		high24hour=excluded.high24hour,
+		price=excluded.price,
+		supply=excluded.supply,
+		mktcap=excluded.mktcap,
+		displaydataraw=excluded.displaydataraw,
+		stored=excluded.stored`
+
+// skipExisting keeps the stored row with the same natural key
+const skipExisting = ` on conflict (fromSym, toSym, lastupdate, provider, ts) do nothing`
+
+// Insert clients.Data from the clients.DataPipe to the Db, the stored row with the same natural key is updated
+func (d *Db) Insert(data *domain.Data) (result sql.Result, err error) {
+	if data == nil {
+		return nil, errors.New("cant insert empty data")
+	}
 	query := `insert into data (
                   fromSym,
                   toSym,
@@ -68,33 +209,137 @@ func (d *Db) Insert(data *domain.Data) (result sql.Result, err error) {
                   open24hour,
                   volume24hour,
                   low24hour,
-                  high24hour, 
+                  high24hour,
                   price,
-                  supply, 
-                  mktcap, 
+                  supply,
+                  mktcap,
                   lastupdate,
-                  displaydataraw
-        ) 
+                  displaydataraw,
+                  provider,
+                  stored
+        )
 		values (
 		        (SELECT _id FROM symbols WHERE symbol=$1),
 		        (SELECT _id FROM symbols WHERE symbol=$2),
-		        $3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13
-		)
-`
-	return d.Exec(
-		query,
-		&data.FromSymbol,
-		&data.ToSymbol,
-		&data.Change24Hour,
-		&data.ChangePct24Hour,
-		&data.Open24Hour,
-		&data.Volume24Hour,
-		&data.Low24Hour,
-		&data.High24Hour,
-		&data.Price,
-		&data.Supply,
-		&data.MktCap,
-		&data.LastUpdate,
-		&data.DisplayDataRaw,
+		        $3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15
+		)` + upsert
+	return d.Exec(query, rowArgs(data, time.Now().UnixMilli())...)
+}
+
+// insertColumns number of the columns of the inserted row
+const insertColumns = 15
+
+// InsertBatch of the data with one multi-row insert, the stored rows with the same natural key are updated
+func (d *Db) InsertBatch(data []*domain.Data) (result sql.Result, err error) {
+	return d.insertBatch(uniqueRows(data), upsert)
+}
+
+// uniqueRows keeps the last one of the rows with the same natural key, because postgres can't update the same row
+// twice in one insert
+func uniqueRows(data []*domain.Data) []*domain.Data {
+	type key struct {
+		from, to, provider string
+		lastUpdate         int64
+	}
+	var (
+		index  = make(map[key]int, len(data))
+		result = make([]*domain.Data, 0, len(data))
+	)
+	for _, row := range data {
+		k := key{row.FromSymbol, row.ToSymbol, row.Provider, row.LastUpdate}
+		if i, ok := index[k]; ok {
+			result[i] = row
+			continue
+		}
+		index[k] = len(result)
+		result = append(result, row)
+	}
+	return result
+}
+
+// InsertMissing rows that are not stored yet, the rows with the same natural key are skipped, so the same rows could
+// be inserted again safely
+func (d *Db) InsertMissing(data []*domain.Data) (inserted int64, err error) {
+	result, err := d.insertBatch(data, skipExisting)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// maxBatchRows is the max number of the rows inserted with one statement, postgres allows 65535 parameters in the
+// statement
+const maxBatchRows = 65535 / insertColumns
+
+// insertBatch of the data, the large batches are split into several multi-row inserts to keep the number of the
+// statement parameters under the limit
+func (d *Db) insertBatch(data []*domain.Data, onConflict string) (result sql.Result, err error) {
+	if len(data) == 0 {
+		return nil, errors.New("cant insert empty batch")
+	}
+	var affected int64
+	for len(data) > 0 {
+		n := len(data)
+		if n > maxBatchRows {
+			n = maxBatchRows
+		}
+		if result, err = d.insertRows(data[:n], onConflict); err != nil {
+			return nil, err
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		affected += rows
+		data = data[n:]
+	}
+	return driver.RowsAffected(affected), nil
+}
+
+// insertRows with one multi-row insert
+func (d *Db) insertRows(data []*domain.Data, onConflict string) (result sql.Result, err error) {
+	var (
+		b      strings.Builder
+		args   = make([]interface{}, 0, len(data)*insertColumns)
+		stored = time.Now().UnixMilli()
 	)
+	b.WriteString(`insert into data (fromSym, toSym, change24hour, changepct24hour, open24hour, volume24hour, ` +
+		`low24hour, high24hour, price, supply, mktcap, lastupdate, displaydataraw, provider, stored) values `)
+	for i, row := range data {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		n := len(args)
+		b.WriteString(fmt.Sprintf(
+			"((SELECT _id FROM symbols WHERE symbol=$%d),(SELECT _id FROM symbols WHERE symbol=$%d)", n+1, n+2,
+		))
+		for j := 3; j <= insertColumns; j++ {
+			b.WriteString(fmt.Sprintf(",$%d", n+j))
+		}
+		b.WriteString(")")
+		args = append(args, rowArgs(row, stored)...)
+	}
+	b.WriteString(onConflict)
+	return d.Exec(b.String(), args...)
+}
+
+// rowArgs of the inserted row, stored is the insert time in unix milliseconds
+func rowArgs(row *domain.Data, stored int64) []interface{} {
+	return []interface{}{
+		row.FromSymbol,
+		row.ToSymbol,
+		row.Change24Hour,
+		row.ChangePct24Hour,
+		row.Open24Hour,
+		row.Volume24Hour,
+		row.Low24Hour,
+		row.High24Hour,
+		row.Price,
+		row.Supply,
+		row.MktCap,
+		row.LastUpdate,
+		row.DisplayDataRaw,
+		row.Provider,
+		stored,
+	}
 }
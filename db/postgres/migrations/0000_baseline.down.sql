@@ -0,0 +1 @@
+-- the upgraded data table is kept, it is dropped by the init migration
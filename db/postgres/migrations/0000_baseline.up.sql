@@ -0,0 +1,19 @@
+-- upgrade the data table created by model/init_postgres/init.sql before the migrations were introduced, the databases
+-- created by the migrations are skipped
+do
+$$
+begin
+    if to_regclass('data') is null or exists(select 1 from schema_migrations where version = 1) then
+        return;
+    end if;
+
+    alter table data add column if not exists provider varchar(32) default '' not null;
+
+    delete from data a using data b
+    where a._id < b._id and a.fromsym = b.fromsym and a.tosym = b.tosym
+      and a.lastupdate = b.lastupdate and a.provider = b.provider;
+
+    create unique index if not exists data_natural_key_uindex
+        on data (fromsym, tosym, lastupdate, provider);
+end
+$$;
@@ -0,0 +1,3 @@
+drop table if exists session;
+drop table if exists symbols;
+drop table if exists data;
@@ -0,0 +1,37 @@
+create table if not exists alerts
+(
+    _id        serial not null primary key,
+    fsym       varchar(64) not null,
+    tsym       varchar(64) not null,
+    condition  varchar(16) not null,
+    value      double precision not null,
+    window_sec bigint default 0 not null,
+    url        text not null,
+    secret     text default '' not null,
+    enabled    boolean default true not null
+);
+
+create table if not exists deliveries
+(
+    _id        serial not null primary key,
+    alert_id   bigint not null,
+    url        text not null,
+    attempt    integer not null,
+    status     integer not null,
+    error      text default '' not null,
+    created_at bigint not null
+);
+
+create index if not exists deliveries_alert_id_index
+    on deliveries (alert_id);
+
+create table if not exists webhooks
+(
+    _id        serial not null primary key,
+    pairs      text default '' not null,
+    url        text not null,
+    secret     text default '' not null,
+    window_sec bigint default 1 not null,
+    enabled    boolean default true not null,
+    failures   integer default 0 not null
+);
@@ -0,0 +1,18 @@
+-- the hypertable can't be turned back into the plain table, so the migration could be reverted only before the
+-- timescaledb setup
+drop materialized view if exists data_candles_1h;
+drop materialized view if exists data_candles_1m;
+
+drop index if exists data_pair_ts_index;
+
+drop index if exists data_natural_key_uindex;
+create unique index data_natural_key_uindex
+    on data (fromsym, tosym, lastupdate, provider);
+
+alter table data drop constraint if exists data_pkey;
+alter table data add primary key (_id);
+
+drop trigger if exists data_set_ts on data;
+drop function if exists data_set_ts();
+
+alter table data drop column if exists ts;
@@ -0,0 +1,36 @@
+-- ts keeps the milliseconds of lastupdate
+alter table data add column if not exists ts timestamptz;
+
+update data
+set ts = to_timestamp(case when lastupdate::bigint > 9999999999 then lastupdate::bigint / 1000.0
+                           else lastupdate::bigint end)
+where ts is null;
+
+alter table data alter column ts set not null;
+
+create or replace function data_set_ts() returns trigger as
+$$
+begin
+    new.ts := to_timestamp(case when new.lastupdate::bigint > 9999999999 then new.lastupdate::bigint / 1000.0
+                                else new.lastupdate::bigint end);
+    return new;
+end
+$$ language plpgsql;
+
+drop trigger if exists data_set_ts on data;
+create trigger data_set_ts
+    before insert or update of lastupdate on data
+    for each row
+execute procedure data_set_ts();
+
+-- the unique indexes of the timescaledb hypertable must include its time column, ts is derived from lastupdate, so
+-- the natural key stays the same
+alter table data drop constraint if exists data_pkey;
+alter table data add primary key (_id, ts);
+
+drop index if exists data_natural_key_uindex;
+create unique index data_natural_key_uindex
+    on data (fromsym, tosym, lastupdate, provider, ts);
+
+create index if not exists data_pair_ts_index
+    on data (fromsym, tosym, ts desc);
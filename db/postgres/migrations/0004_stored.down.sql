@@ -0,0 +1,3 @@
+drop index if exists data_pair_stored_index;
+
+alter table data drop column if exists stored;
@@ -0,0 +1,6 @@
+-- stored is the insert time of the row in unix milliseconds, it is set by the service, so the rows missed by the
+-- reconnecting stream peers could be found by the same clock as the live events
+alter table data add column if not exists stored bigint;
+
+create index if not exists data_pair_stored_index
+    on data (fromsym, tosym, stored, _id);
@@ -0,0 +1,220 @@
+package postgres
+
+import (
+	"fmt"
+)
+
+// aggregate continuous aggregate of the candles over the data hypertable
+type aggregate struct {
+	view     string
+	bucket   int64  // seconds
+	interval string // postgres interval of the bucket
+	start    string // start offset of the refresh policy, older buckets are not refreshed
+	schedule string // schedule interval of the refresh policy
+	candles  string // query of the candles rolled up from the aggregate, the same parameters as the raw query
+}
+
+// aggregates from the widest bucket, the candles use the first one that fits the interval
+var aggregates = []*aggregate{
+	newAggregate("data_candles_1h", 3600, "1 hour", "3 days", "1 hour"),
+	newAggregate("data_candles_1m", 60, "1 minute", "1 hour", "1 minute"),
+}
+
+// refreshWindow of the aggregates in days, the raw data must be kept longer, otherwise the aggregates lose it
+const refreshWindow = 3
+
+func newAggregate(view string, bucket int64, interval, start, schedule string) *aggregate {
+	return &aggregate{
+		view:     view,
+		bucket:   bucket,
+		interval: interval,
+		start:    start,
+		schedule: schedule,
+		candles: `
+		select t, open, high, low, close, volume, ticks
+		from (
+		    select
+		        (extract(epoch from bucket)::bigint / $3::bigint) * $3::bigint as t,
+		        (array_agg(open order by bucket))[1] as open,
+		        max(high) as high,
+		        min(low) as low,
+		        (array_agg(close order by bucket desc))[1] as close,
+		        (array_agg(volume order by bucket desc))[1] as volume,
+		        sum(ticks)::bigint as ticks
+		    from ` + view + `
+		    where fromSym=(select _id from symbols where symbol=$1)
+		      and toSym=(select _id from symbols where symbol=$2)
+		      and ($4::bigint = 0 or bucket > to_timestamp($4::bigint) - interval '` + interval + `')
+		      and ($5::bigint = 0 or bucket <= to_timestamp($5::bigint))
+		    group by t
+		    ORDER BY t DESC limit $6
+		) as c
+		ORDER BY t;
+`,
+	}
+}
+
+// Aggregates availability states
+const (
+	aggregatesUnknown int32 = iota
+	aggregatesAvailable
+	aggregatesMissing
+)
+
+// aggregateOf returns the continuous aggregate with the bucket that fits the interval, or nil if there is no one
+func (d *Db) aggregateOf(interval int64) *aggregate {
+	if !d.hasAggregates() {
+		return nil
+	}
+	for _, a := range aggregates {
+		if interval%a.bucket == 0 {
+			return a
+		}
+	}
+	return nil
+}
+
+// hasAggregates checks once if the continuous aggregates exist, e.g. created on the previous start
+func (d *Db) hasAggregates() bool {
+	switch d.aggregates.Load() {
+	case aggregatesAvailable:
+		return true
+	case aggregatesMissing:
+		return false
+	}
+	available := true
+	for _, a := range aggregates {
+		var exists bool
+		if err := d.QueryRow(`select to_regclass($1) is not null`, a.view).Scan(&exists); err != nil {
+			return false
+		}
+		available = available && exists
+	}
+	if available {
+		d.aggregates.Store(aggregatesAvailable)
+	} else {
+		d.aggregates.Store(aggregatesMissing)
+	}
+	return available
+}
+
+// SetupTimescale turns the data table into the hypertable, creates the continuous aggregates of the candles and the
+// compression and retention policies if the timescaledb extension is installed. The compressAfter and retention are
+// in days, zero removes the policy. Returns false if the extension is not installed.
+func (d *Db) SetupTimescale(compressAfter, retention int) (enabled bool, err error) {
+	if err = d.QueryRow(
+		`select exists(select 1 from pg_extension where extname='timescaledb')`,
+	).Scan(&enabled); err != nil || !enabled {
+		return false, err
+	}
+	if retention > 0 && retention <= refreshWindow {
+		return true, fmt.Errorf("timescale retention should be longer than %d days", refreshWindow)
+	}
+	if _, err = d.Exec(
+		`select create_hypertable('data', 'ts', migrate_data => true, if_not_exists => true);`,
+	); err != nil {
+		return true, fmt.Errorf("failed to create hypertable: %w", err)
+	}
+	for _, a := range aggregates {
+		if err = d.createAggregate(a); err != nil {
+			return true, fmt.Errorf("failed to create %s: %w", a.view, err)
+		}
+	}
+	d.aggregates.Store(aggregatesAvailable)
+	if err = d.compressionPolicy(compressAfter); err != nil {
+		return true, fmt.Errorf("failed to set compression policy: %w", err)
+	}
+	if _, err = d.Exec(`select remove_retention_policy('data', if_exists => true);`); err != nil {
+		return true, fmt.Errorf("failed to remove retention policy: %w", err)
+	}
+	if retention > 0 {
+		if _, err = d.Exec(
+			`select add_retention_policy('data', make_interval(days => $1::int));`, retention,
+		); err != nil {
+			return true, fmt.Errorf("failed to add retention policy: %w", err)
+		}
+	}
+	return true, nil
+}
+
+// createAggregate with the refresh policy, the new aggregate is materialized over all the stored data at once, the
+// real time aggregation adds the data newer than the last refresh
+func (d *Db) createAggregate(a *aggregate) (err error) {
+	var exists bool
+	if err = d.QueryRow(`select to_regclass($1) is not null`, a.view).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		if _, err = d.Exec(`
+			create materialized view ` + a.view + `
+			with (timescaledb.continuous, timescaledb.materialized_only = false) as
+			select
+			    fromsym,
+			    tosym,
+			    time_bucket(interval '` + a.interval + `', ts) as bucket,
+			    first(price, ts) as open,
+			    max(price) as high,
+			    min(price) as low,
+			    last(price, ts) as close,
+			    last(volume24hour, ts) as volume,
+			    count(*) as ticks
+			from data
+			group by fromsym, tosym, bucket
+			with no data;`,
+		); err != nil {
+			return err
+		}
+		if _, err = d.Exec(`call refresh_continuous_aggregate('` + a.view + `', null, null);`); err != nil {
+			return err
+		}
+	}
+	_, err = d.Exec(`select add_continuous_aggregate_policy('` + a.view + `',
+		start_offset => interval '` + a.start + `',
+		end_offset => interval '` + a.interval + `',
+		schedule_interval => interval '` + a.schedule + `',
+		if_not_exists => true);`,
+	)
+	return err
+}
+
+// compressionPolicy compresses the hypertable chunks older than the selected days, the rows are segmented by the
+// currencies pair, so the queries of one pair decompress only its segments
+func (d *Db) compressionPolicy(days int) (err error) {
+	if _, err = d.Exec(`select remove_compression_policy('data', if_exists => true);`); err != nil || days <= 0 {
+		return err
+	}
+	var compressed bool
+	if err = d.QueryRow(
+		`select compression_enabled from timescaledb_information.hypertables where hypertable_name='data'`,
+	).Scan(&compressed); err != nil {
+		return err
+	}
+	if !compressed {
+		if _, err = d.Exec(`alter table data set (
+			timescaledb.compress,
+			timescaledb.compress_segmentby = 'fromsym, tosym',
+			timescaledb.compress_orderby = 'ts desc, _id desc'
+		);`); err != nil {
+			return err
+		}
+	}
+	_, err = d.Exec(`select add_compression_policy('data', make_interval(days => $1::int));`, days)
+	return err
+}
+
+// CompressAfter return the days the hypertable chunks are compressed after by the compression policy, zero if the
+// timescaledb extension is not installed or the chunks are not compressed
+func (d *Db) CompressAfter() (days int, err error) {
+	var installed bool
+	if err = d.QueryRow(
+		`select exists(select 1 from pg_extension where extname='timescaledb')`,
+	).Scan(&installed); err != nil || !installed {
+		return 0, err
+	}
+	err = d.QueryRow(`
+		select coalesce(min(ceil(extract(epoch from (config->>'compress_after')::interval) / 86400)), 0)::int
+		from timescaledb_information.jobs
+		where proc_name = 'policy_compression' and hypertable_name = 'data';`,
+	).Scan(&days)
+	return days, err
+}
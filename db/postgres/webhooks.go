@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/streamdp/ccd/domain"
+)
+
+func (d *Db) Webhooks() (webhooks []*domain.Webhook, err error) {
+	rows, err := d.Query(`select _id, pairs, url, secret, window_sec, enabled, failures from webhooks order by _id`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	for rows.Next() {
+		var (
+			w     = &domain.Webhook{}
+			pairs string
+		)
+		if err = rows.Scan(&w.Id, &pairs, &w.Url, &w.Secret, &w.Window, &w.Enabled, &w.Failures); err != nil {
+			return nil, err
+		}
+		if pairs != "" {
+			w.Pairs = strings.Split(pairs, ",")
+		}
+		webhooks = append(webhooks, w)
+	}
+	return webhooks, rows.Err()
+}
+
+func (d *Db) AddWebhook(w *domain.Webhook) (id int64, err error) {
+	if w == nil {
+		return 0, errors.New("cant insert empty webhook")
+	}
+	err = d.QueryRow(
+		`insert into webhooks (pairs, url, secret, window_sec, enabled, failures)
+			values ($1,$2,$3,$4,$5,$6) returning _id;`,
+		strings.Join(w.Pairs, ","), w.Url, w.Secret, w.Window, w.Enabled, w.Failures,
+	).Scan(&id)
+	return
+}
+
+func (d *Db) UpdateWebhook(w *domain.Webhook) (result sql.Result, err error) {
+	if w == nil {
+		return nil, errors.New("empty webhook")
+	}
+	return d.Exec(
+		`update webhooks set pairs=$2, url=$3, secret=$4, window_sec=$5, enabled=$6, failures=$7 where _id=$1;`,
+		w.Id, strings.Join(w.Pairs, ","), w.Url, w.Secret, w.Window, w.Enabled, w.Failures,
+	)
+}
+
+func (d *Db) RemoveWebhook(id int64) (result sql.Result, err error) {
+	return d.Exec(`delete from webhooks where _id=$1;`, id)
+}
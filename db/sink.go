@@ -0,0 +1,43 @@
+package db
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/streamdp/ccd/config"
+	"github.com/streamdp/ccd/db/kafka"
+	"github.com/streamdp/ccd/db/nats"
+)
+
+// Sink interface makes it possible to publish every stored tick to the external event bus, the key is the FROM:TO
+// currencies pair and the data is the json encoded domain.Data
+type Sink interface {
+	Publish(key string, data []byte) error
+	Close() error
+}
+
+// newSinks selected by the "CCDC_SINK" comma separated list, e.g. "nats,kafka"
+func newSinks(l *log.Logger) (sinks []Sink, err error) {
+	for _, name := range strings.Split(config.GetEnv("CCDC_SINK"), ",") {
+		var s Sink
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "":
+			continue
+		case nats.Nats:
+			if s, err = nats.NewSink(config.GetEnv("CCDC_NATSURL"), config.GetEnv("CCDC_NATSSUBJECT")); err != nil {
+				return nil, err
+			}
+		case kafka.Kafka:
+			var brokers []string
+			if b := config.GetEnv("CCDC_KAFKABROKERS"); b != "" {
+				brokers = strings.Split(b, ",")
+			}
+			s = kafka.NewSink(brokers, config.GetEnv("CCDC_KAFKATOPIC"), l)
+		default:
+			return nil, fmt.Errorf("unknown sink %q", name)
+		}
+		sinks = append(sinks, s)
+	}
+	return sinks, nil
+}
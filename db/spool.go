@@ -0,0 +1,163 @@
+package db
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/streamdp/ccd/domain"
+)
+
+// spool is the append-only json lines file of the rows waiting to be inserted, the rows are read back in the same
+// order. The read position is kept in the ".offset" file next to it, so the pending rows survive restarts.
+type spool struct {
+	path    string
+	w       *os.File
+	r       *os.File
+	offset  int64 // read position
+	end     int64 // end of the last complete line
+	pending int64
+	mu      sync.Mutex
+}
+
+func openSpool(path string) (s *spool, err error) {
+	s = &spool{
+		path: path,
+	}
+	if s.w, err = os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644); err != nil {
+		return nil, err
+	}
+	// the pending rows would be inserted twice if another process shared the spool
+	if err = lockFile(s.w); err != nil {
+		_ = s.w.Close()
+		return nil, fmt.Errorf("failed to lock %s, is it used by another process: %w", path, err)
+	}
+	if s.r, err = os.Open(path); err != nil {
+		_ = s.w.Close()
+		return nil, err
+	}
+	if b, err := os.ReadFile(s.offsetPath()); err == nil {
+		s.offset, _ = strconv.ParseInt(string(b), 10, 64)
+	}
+	info, err := s.w.Stat()
+	if err != nil {
+		return nil, s.closeWithError(err)
+	}
+	// the spool was truncated, but the offset wasn't saved
+	if s.offset > info.Size() {
+		s.offset = 0
+	}
+	if _, err = s.r.Seek(s.offset, io.SeekStart); err != nil {
+		return nil, s.closeWithError(err)
+	}
+	// count the lines the same way they are read, the last line without the line break was torn by the crash in the
+	// middle of the write, it is truncated, so the next rows don't get glued to it
+	s.end = s.offset
+	br := bufio.NewReader(s.r)
+	for {
+		line, err := br.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			if len(line) > 0 {
+				if err = s.w.Truncate(s.end); err != nil {
+					return nil, s.closeWithError(err)
+				}
+			}
+			break
+		}
+		if err != nil {
+			return nil, s.closeWithError(err)
+		}
+		s.end += int64(len(line))
+		s.pending++
+	}
+	return s, nil
+}
+
+func (s *spool) offsetPath() string {
+	return s.path + ".offset"
+}
+
+// write rows to the end of the spool
+func (s *spool) write(data ...*domain.Data) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var b []byte
+	for _, d := range data {
+		line, err := json.Marshal(d)
+		if err != nil {
+			return err
+		}
+		b = append(append(b, line...), '\n')
+	}
+	if _, err := s.w.Write(b); err != nil {
+		// drop the partially written rows
+		_ = s.w.Truncate(s.end)
+		return err
+	}
+	s.end += int64(len(b))
+	s.pending += int64(len(data))
+	return nil
+}
+
+// read up to n oldest pending rows, they stay pending until commit. It returns the read position and the number of
+// lines after the rows.
+func (s *spool) read(n int) (data []*domain.Data, next int64, lines int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err = s.r.Seek(s.offset, io.SeekStart); err != nil {
+		return nil, 0, 0, err
+	}
+	next = s.offset
+	br := bufio.NewReader(s.r)
+	for lines < n {
+		line, err := br.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		next += int64(len(line))
+		lines++
+		d := &domain.Data{}
+		if err = json.Unmarshal(line, d); err != nil {
+			// skip the broken row instead of getting stuck on it
+			continue
+		}
+		data = append(data, d)
+	}
+	return data, next, lines, nil
+}
+
+// commit the read position after the rows were inserted, the file is truncated when all rows are committed
+func (s *spool) commit(next int64, lines int) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.offset = next
+	if s.pending -= int64(lines); s.pending <= 0 {
+		s.pending = 0
+		if err = s.w.Truncate(0); err != nil {
+			return err
+		}
+		s.offset, s.end = 0, 0
+	}
+	return os.WriteFile(s.offsetPath(), []byte(strconv.FormatInt(s.offset, 10)), 0o644)
+}
+
+// len return the number of pending rows
+func (s *spool) len() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pending
+}
+
+func (s *spool) closeWithError(err error) error {
+	_ = s.r.Close()
+	_ = s.w.Close()
+	return err
+}
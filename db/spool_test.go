@@ -0,0 +1,64 @@
+package db
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSpoolLocked(t *testing.T) {
+	path := filepath.Join(t.TempDir(), bufferFile)
+	s, err := openSpool(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = openSpool(path); err == nil {
+		t.Error("got no error opening the spool used by another writer")
+	}
+	_ = s.closeWithError(nil)
+	if s, err = openSpool(path); err != nil {
+		t.Fatalf("got error %v opening the released spool", err)
+	}
+	_ = s.closeWithError(nil)
+}
+
+func TestSpoolTornLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), bufferFile)
+	s, err := openSpool(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = s.write(testTick(0), testTick(1)); err != nil {
+		t.Fatal(err)
+	}
+	// the crash in the middle of the write
+	if _, err = s.w.WriteString(`{"fromSymbol":"BTC","toSym`); err != nil {
+		t.Fatal(err)
+	}
+	_ = s.closeWithError(nil)
+
+	if s, err = openSpool(path); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = s.closeWithError(nil)
+	})
+	if n := s.len(); n != 2 {
+		t.Fatalf("got %d pending rows, want 2", n)
+	}
+	if err = s.write(testTick(2)); err != nil {
+		t.Fatal(err)
+	}
+	data, next, lines, err := s.read(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 3 || lines != 3 || data[2].Price != 2 {
+		t.Fatalf("got %d rows of %d lines, want 3 rows", len(data), lines)
+	}
+	if err = s.commit(next, lines); err != nil {
+		t.Fatal(err)
+	}
+	if n := s.len(); n != 0 {
+		t.Errorf("got %d pending rows after commit, want 0", n)
+	}
+}
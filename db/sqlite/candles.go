@@ -0,0 +1,60 @@
+package sqlite
+
+import (
+	"github.com/streamdp/ccd/domain"
+)
+
+// Candles for the selected currencies pair aggregated over the interval (in seconds) buckets in the [start,end]
+// time range, the last limit candles are returned in chronological order
+func (d *Db) Candles(from, to string, interval, start, end int64, limit int) (result []*domain.Candle, err error) {
+	query := `
+		select bucket, open, high, low, close, volume, ticks
+		from (
+		    select
+		        bucket,
+		        max(case when first = 1 then price end) as open,
+		        max(price) as high,
+		        min(price) as low,
+		        max(case when last = 1 then price end) as close,
+		        max(case when last = 1 then volume24hour end) as volume,
+		        count(*) as ticks
+		    from (
+		        select
+		            price,
+		            volume24hour,
+		            bucket,
+		            row_number() over (partition by bucket order by ts, _id) as first,
+		            row_number() over (partition by bucket order by ts desc, _id desc) as last
+		        from (
+		            select _id, price, volume24hour, ts, ts / ? * ? as bucket
+		            from (
+		                select _id, price, volume24hour, ` + lastUpdateUnix + ` as ts
+		                from data
+		                where fromSym=(select _id from symbols where symbol=?)
+		                  and toSym=(select _id from symbols where symbol=?)
+		            ) as d
+		            where (? = 0 or ts >= ?)
+		              and (? = 0 or ts <= ?)
+		        ) as b
+		    ) as r
+		    group by bucket
+		    ORDER BY bucket DESC limit ?
+		) as c
+		ORDER BY bucket;
+`
+	rows, err := d.Query(query, interval, interval, from, to, start, start, end, end, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	for rows.Next() {
+		c := &domain.Candle{}
+		if err = rows.Scan(&c.Time, &c.Open, &c.High, &c.Low, &c.Close, &c.Volume, &c.Ticks); err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,47 @@
+package sqlite
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/streamdp/ccd/domain"
+	_ "modernc.org/sqlite"
+)
+
+const Sqlite = "sqlite"
+
+// pragmas of the connection, the wal journal and the busy timeout let the readers and the writer work concurrently
+const pragmas = "_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)"
+
+// Db needed to add new methods for an instance *sql.Db
+type Db struct {
+	*sql.DB
+	pipe chan *domain.Data
+}
+
+func (d *Db) DataPipe() chan *domain.Data {
+	return d.pipe
+}
+
+// Connect after prepare to the Db, the data source is the path to the database file
+func Connect(dataSource string) (db *Db, err error) {
+	if strings.Contains(dataSource, "?") {
+		dataSource += "&" + pragmas
+	} else {
+		dataSource += "?" + pragmas
+	}
+	sqlDb := &sql.DB{}
+	if sqlDb, err = sql.Open(Sqlite, dataSource); err != nil {
+		return
+	}
+	return &Db{
+		DB:   sqlDb,
+		pipe: make(chan *domain.Data, 1000),
+	}, nil
+}
+
+// Close Db connection
+func (d *Db) Close() (err error) {
+	defer close(d.pipe)
+	return d.DB.Close()
+}
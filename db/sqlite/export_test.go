@@ -0,0 +1,4 @@
+package sqlite
+
+// MaxBatchRows is exported for the tests
+const MaxBatchRows = maxBatchRows
@@ -0,0 +1,38 @@
+package sqlite
+
+import (
+	"embed"
+	"io/fs"
+
+	"github.com/streamdp/ccd/db/migrate"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+var dialect = migrate.Dialect{
+	CreateTable: `create table if not exists schema_migrations
+		(version bigint not null primary key, name varchar(255) not null, applied_at bigint not null);`,
+	Insert: `insert into schema_migrations (version, name, applied_at) values (?, ?, ?);`,
+	Delete: `delete from schema_migrations where version=?;`,
+	Split:  true,
+	Tx:     true,
+}
+
+// MigrateUp applies the pending embedded migrations
+func (d *Db) MigrateUp() (applied int, err error) {
+	m, err := fs.Sub(migrations, "migrations")
+	if err != nil {
+		return 0, err
+	}
+	return migrate.Up(d.DB, m, dialect)
+}
+
+// MigrateDown reverts the selected number of the last applied migrations
+func (d *Db) MigrateDown(steps int) (reverted int, err error) {
+	m, err := fs.Sub(migrations, "migrations")
+	if err != nil {
+		return 0, err
+	}
+	return migrate.Down(d.DB, m, dialect, steps)
+}
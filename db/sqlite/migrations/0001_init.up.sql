@@ -0,0 +1,46 @@
+create table if not exists data
+(
+    _id             integer primary key autoincrement,
+    fromSym         integer not null,
+    toSym           integer not null,
+    change24hour    real    null,
+    changepct24hour real    null,
+    open24hour      real    null,
+    volume24hour    real    null,
+    low24hour       real    null,
+    high24hour      real    null,
+    price           real    null,
+    supply          real    null,
+    mktcap          real    null,
+    lastupdate      text    not null,
+    displaydataraw  text    null,
+    provider        text    default '' not null,
+    constraint data_natural_key_uindex unique (fromSym, toSym, lastupdate, provider)
+);
+
+create table if not exists symbols
+(
+    _id     integer primary key autoincrement,
+    symbol  text default '' not null,
+    unicode text null,
+    constraint symbols_symbol_uindex unique (symbol)
+);
+
+insert or ignore into symbols(symbol, unicode)
+    values ('USDT','₮'),
+           ('BTC','₿'),
+           ('ETH','⟠'),
+           ('USD','$'),
+           ('XRP','✕'),
+           ('LTC','Ł'),
+           ('EUR','€'),
+           ('GBP','£'),
+           ('JPY','¥');
+
+create table if not exists session
+(
+    _id        integer primary key autoincrement,
+    task_name  text    default '' not null,
+    "interval" integer default 60 not null,
+    constraint session_task_name_uindex unique (task_name)
+);
@@ -0,0 +1,36 @@
+create table if not exists alerts
+(
+    _id         integer primary key autoincrement,
+    fsym        text    not null,
+    tsym        text    not null,
+    "condition" text    not null,
+    value       real    not null,
+    window_sec  integer default 0 not null,
+    url         text    not null,
+    secret      text    not null,
+    enabled     boolean default true not null
+);
+
+create table if not exists deliveries
+(
+    _id        integer primary key autoincrement,
+    alert_id   integer not null,
+    url        text    not null,
+    attempt    integer not null,
+    status     integer not null,
+    error      text    not null,
+    created_at integer not null
+);
+
+create index if not exists deliveries_alert_id_index on deliveries (alert_id);
+
+create table if not exists webhooks
+(
+    _id        integer primary key autoincrement,
+    pairs      text    not null,
+    url        text    not null,
+    secret     text    not null,
+    window_sec integer default 1 not null,
+    enabled    boolean default true not null,
+    failures   integer default 0 not null
+);
@@ -0,0 +1,63 @@
+package sqlite
+
+import (
+	"github.com/streamdp/ccd/domain"
+)
+
+// Pairs of the currencies with the stored data
+func (d *Db) Pairs() (pairs []string, err error) {
+	rows, err := d.Query(`
+		select f.symbol, t.symbol
+		from symbols f, symbols t
+		where exists(select 1 from data where fromSym=f._id and toSym=t._id)
+		ORDER BY f.symbol, t.symbol;
+`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	for rows.Next() {
+		var from, to string
+		if err = rows.Scan(&from, &to); err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, domain.PairName(from, to))
+	}
+	return pairs, rows.Err()
+}
+
+// Downsample the data of the selected currencies pair older than before (unix seconds), only the open, high, low and
+// close rows of every provider in every bucket (in seconds) are kept, so the candles of the bucket stay the same
+// except the number of ticks. Zero bucket deletes all the rows.
+func (d *Db) Downsample(from, to string, before, bucket int64) (deleted int64, err error) {
+	query := `
+		delete from data
+		where _id in (
+		    select _id
+		    from (
+		        select
+		            _id,
+		            row_number() over (w order by ts, _id) as open,
+		            row_number() over (w order by price desc, _id) as high,
+		            row_number() over (w order by price, _id) as low,
+		            row_number() over (w order by ts desc, _id desc) as close
+		        from (
+		            select _id, provider, price, ` + lastUpdateUnix + ` as ts
+		            from data
+		            where fromSym=(select _id from symbols where symbol=?)
+		              and toSym=(select _id from symbols where symbol=?)
+		        ) as p
+		        where ts < ?
+		        window w as (partition by provider, ts / max(?, 1))
+		    ) as r
+		    where ? = 0 or (open > 1 and high > 1 and low > 1 and close > 1)
+		);
+`
+	result, err := d.Exec(query, from, to, before, bucket, bucket)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
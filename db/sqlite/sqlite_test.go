@@ -0,0 +1,73 @@
+package sqlite_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/streamdp/ccd/db/dbtest"
+	"github.com/streamdp/ccd/db/sqlite"
+	"github.com/streamdp/ccd/domain"
+)
+
+// newTestDb in the temp file with the migrations applied
+func newTestDb(t *testing.T) *sqlite.Db {
+	d, err := sqlite.Connect(filepath.Join(t.TempDir(), "ccd.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = d.Close()
+	})
+	if _, err = d.MigrateUp(); err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestInsertLargeBatch(t *testing.T) {
+	d := newTestDb(t)
+	rows := make([]*domain.Data, 2*sqlite.MaxBatchRows+1)
+	for i := range rows {
+		rows[i] = &domain.Data{FromSymbol: "BTC", ToSymbol: "USD", Price: float64(i), LastUpdate: 1705313045000 +
+			int64(i), Provider: "test"}
+	}
+	result, err := d.InsertBatch(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := result.RowsAffected(); err != nil || n != int64(len(rows)) {
+		t.Errorf("got %d affected rows (%v), want %d", n, err, len(rows))
+	}
+	if n, err := d.InsertMissing(rows); err != nil || n != 0 {
+		t.Errorf("got %d inserted missing rows (%v), want 0", n, err)
+	}
+	last, err := d.GetLast("BTC", "USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last.Price != float64(len(rows)-1) {
+		t.Errorf("got the last price %v, want %d", last.Price, len(rows)-1)
+	}
+}
+
+func TestConformance(t *testing.T) {
+	dbtest.Run(t, newTestDb(t))
+}
+
+func TestGetLastOrder(t *testing.T) {
+	d := newTestDb(t)
+	// the text of the older time in seconds is greater than the newer time in milliseconds
+	for _, lastUpdate := range []int64{1705313045001, 1705313045000, 999999999, 1705313044} {
+		if _, err := d.Insert(&domain.Data{FromSymbol: "BTC", ToSymbol: "USD", Price: float64(lastUpdate),
+			LastUpdate: lastUpdate, Provider: "test"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	last, err := d.GetLast("BTC", "USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last.LastUpdate != 1705313045001 {
+		t.Errorf("got the last update %d, want 1705313045001", last.LastUpdate)
+	}
+}
@@ -0,0 +1,61 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/streamdp/ccd/domain"
+)
+
+func (d *Db) Webhooks() (webhooks []*domain.Webhook, err error) {
+	rows, err := d.Query(`select _id, pairs, url, secret, window_sec, enabled, failures from webhooks order by _id`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	for rows.Next() {
+		var (
+			w     = &domain.Webhook{}
+			pairs string
+		)
+		if err = rows.Scan(&w.Id, &pairs, &w.Url, &w.Secret, &w.Window, &w.Enabled, &w.Failures); err != nil {
+			return nil, err
+		}
+		if pairs != "" {
+			w.Pairs = strings.Split(pairs, ",")
+		}
+		webhooks = append(webhooks, w)
+	}
+	return webhooks, rows.Err()
+}
+
+func (d *Db) AddWebhook(w *domain.Webhook) (id int64, err error) {
+	if w == nil {
+		return 0, errors.New("cant insert empty webhook")
+	}
+	result, err := d.Exec(
+		`insert into webhooks (pairs, url, secret, window_sec, enabled, failures) values (?,?,?,?,?,?);`,
+		strings.Join(w.Pairs, ","), w.Url, w.Secret, w.Window, w.Enabled, w.Failures,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (d *Db) UpdateWebhook(w *domain.Webhook) (result sql.Result, err error) {
+	if w == nil {
+		return nil, errors.New("empty webhook")
+	}
+	return d.Exec(
+		`update webhooks set pairs=?, url=?, secret=?, window_sec=?, enabled=?, failures=? where _id=?;`,
+		strings.Join(w.Pairs, ","), w.Url, w.Secret, w.Window, w.Enabled, w.Failures, w.Id,
+	)
+}
+
+func (d *Db) RemoveWebhook(id int64) (result sql.Result, err error) {
+	return d.Exec(`delete from webhooks where _id=?;`, id)
+}
@@ -0,0 +1,338 @@
+package db
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/streamdp/ccd/config"
+	"github.com/streamdp/ccd/domain"
+)
+
+// Overflow policies of the write queue
+const (
+	OverflowBlock      = "block"       // producers wait until there is room in the queue
+	OverflowDropOldest = "drop-oldest" // the oldest queued row is dropped
+	OverflowSpill      = "spill"       // rows are spilled to disk and inserted when the queue is drained
+)
+
+const (
+	spillFile    = "ccd-spill.jsonl"
+	bufferFile   = "ccd-failed.jsonl"
+	rejectedFile = "ccd-rejected.jsonl"
+	// maxAttempts to insert the buffered row one at a time before it is rejected
+	maxAttempts = 5
+)
+
+// pinger is implemented by the databases that could check the connection, a row is rejected only if the database is
+// reachable, otherwise the rows would be rejected while the database is down
+type pinger interface {
+	Ping() error
+}
+
+// Metrics of the writer are published at /debug/vars
+var Metrics = expvar.NewMap("writer")
+
+// Writer inserts the queued rows in batches, the batch is flushed when it is full or the flush interval passed.
+// The batches that failed to insert are written to the on-disk buffer and replayed in the same order once the
+// database recovers, the next batches wait in the buffer behind them.
+type Writer struct {
+	d         Database
+	l         *log.Logger
+	queue     chan *domain.Data
+	overflow  string
+	spill     *spool
+	buffer    *spool
+	rejects   *spool
+	attempts  int // failed attempts to insert the first buffered row
+	batchSize int
+	interval  time.Duration
+	sinks     []Sink
+	done      chan struct{} // closed to stop reading the data pipe
+	stopped   chan struct{} // closed when the last batch is flushed
+	closeOnce sync.Once
+
+	dropped, spilled, inserted, failed, replayed, rejected *expvar.Int
+}
+
+func newWriter(d Database, l *log.Logger, sinks []Sink) (w *Writer, err error) {
+	w = &Writer{
+		d:         d,
+		l:         l,
+		queue:     make(chan *domain.Data, config.QueueSize),
+		overflow:  config.Overflow,
+		batchSize: config.BatchSize,
+		interval:  time.Duration(config.FlushInterval) * time.Millisecond,
+		sinks:     sinks,
+		done:      make(chan struct{}),
+		stopped:   make(chan struct{}),
+		dropped:   new(expvar.Int),
+		spilled:   new(expvar.Int),
+		inserted:  new(expvar.Int),
+		failed:    new(expvar.Int),
+		replayed:  new(expvar.Int),
+		rejected:  new(expvar.Int),
+	}
+	if w.batchSize <= 0 {
+		w.batchSize = 1
+	}
+	if w.interval <= 0 {
+		w.interval = time.Second
+	}
+	if err = os.MkdirAll(config.SpillDir, 0o755); err != nil {
+		return nil, err
+	}
+	if w.buffer, err = openSpool(filepath.Join(config.SpillDir, bufferFile)); err != nil {
+		return nil, err
+	}
+	if w.rejects, err = openSpool(filepath.Join(config.SpillDir, rejectedFile)); err != nil {
+		return nil, err
+	}
+	switch w.overflow {
+	case OverflowBlock, OverflowDropOldest:
+	case OverflowSpill:
+		if w.spill, err = openSpool(filepath.Join(config.SpillDir, spillFile)); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown overflow policy %q", w.overflow)
+	}
+	Metrics.Set("queue_depth", expvar.Func(func() interface{} {
+		return len(w.queue)
+	}))
+	Metrics.Set("spill_depth", expvar.Func(func() interface{} {
+		if w.spill == nil {
+			return 0
+		}
+		return w.spill.len()
+	}))
+	Metrics.Set("buffer_depth", expvar.Func(func() interface{} {
+		return w.buffer.len()
+	}))
+	Metrics.Set("dropped_rows", w.dropped)
+	Metrics.Set("spilled_rows", w.spilled)
+	Metrics.Set("inserted_rows", w.inserted)
+	Metrics.Set("failed_rows", w.failed)
+	Metrics.Set("replayed_rows", w.replayed)
+	Metrics.Set("rejected_rows", w.rejected)
+	return w, nil
+}
+
+// Close the writer, it stops reading the data pipe and returns when the rows left in the pipe and in the queue are
+// flushed and the sinks are closed, so nothing is lost on exit. The rows sent to the pipe after that are not stored.
+func (w *Writer) Close() {
+	w.closeOnce.Do(func() {
+		close(w.done)
+	})
+	<-w.stopped
+}
+
+// push the row to the queue according to the overflow policy
+func (w *Writer) push(data *domain.Data) {
+	switch w.overflow {
+	case OverflowDropOldest:
+		for {
+			select {
+			case w.queue <- data:
+				return
+			default:
+			}
+			select {
+			case <-w.queue:
+				w.dropped.Add(1)
+			default:
+			}
+		}
+	case OverflowSpill:
+		// keep the order, the new rows go to disk until the spilled ones are inserted
+		if w.spill.len() == 0 {
+			select {
+			case w.queue <- data:
+				return
+			default:
+			}
+		}
+		if err := w.spill.write(data); err != nil {
+			w.l.Println(err)
+			w.dropped.Add(1)
+			return
+		}
+		w.spilled.Add(1)
+	default:
+		w.queue <- data
+	}
+}
+
+func (w *Writer) run() {
+	defer close(w.stopped)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	batch := make([]*domain.Data, 0, w.batchSize)
+	for {
+		select {
+		case data, ok := <-w.queue:
+			if !ok {
+				w.flush(batch)
+				w.closeSinks()
+				return
+			}
+			if batch = append(batch, data); len(batch) < w.batchSize {
+				continue
+			}
+		case <-ticker.C:
+		}
+		w.replay()
+		w.flush(batch)
+		batch = batch[:0]
+		w.unspill()
+	}
+}
+
+// flush the batch to the database and the sinks, the batch goes to the buffer if the insert failed or there are
+// buffered rows to keep the order
+func (w *Writer) flush(batch []*domain.Data) {
+	if len(batch) == 0 {
+		return
+	}
+	if w.buffer.len() == 0 {
+		_, err := w.d.InsertBatch(batch)
+		if err == nil {
+			w.inserted.Add(int64(len(batch)))
+			w.publish(batch)
+			return
+		}
+		w.l.Println(err)
+		w.failed.Add(int64(len(batch)))
+	}
+	if err := w.buffer.write(batch...); err != nil {
+		w.l.Println(fmt.Errorf("%d rows lost: %w", len(batch), err))
+		w.dropped.Add(int64(len(batch)))
+	}
+}
+
+// replay the buffered rows in order until the buffer is empty or the database fails again, the rows that were
+// already stored by the failed insert are skipped. If the batch fails, its rows are inserted one at a time, so the
+// row the database rejects doesn't hold the rest.
+func (w *Writer) replay() {
+	for w.buffer.len() > 0 {
+		data, next, lines, err := w.buffer.read(w.batchSize)
+		if err != nil {
+			w.l.Println(err)
+			return
+		}
+		if len(data) > 0 {
+			n, err := w.d.InsertMissing(data)
+			if err != nil {
+				w.l.Println(err)
+				if !w.replayRows(lines) {
+					return
+				}
+				continue
+			}
+			w.attempts = 0
+			w.replayed.Add(n)
+			w.publish(data)
+		}
+		if err = w.buffer.commit(next, lines); err != nil {
+			w.l.Println(err)
+			return
+		}
+	}
+}
+
+// replayRows inserts up to n buffered rows one at a time, it returns false if the row failed to insert. The row is
+// moved to the rejected file after several failed attempts while the database is reachable.
+func (w *Writer) replayRows(n int) bool {
+	for ; n > 0 && w.buffer.len() > 0; n-- {
+		data, next, lines, err := w.buffer.read(1)
+		if err != nil {
+			w.l.Println(err)
+			return false
+		}
+		if len(data) > 0 {
+			inserted, err := w.d.InsertMissing(data)
+			switch {
+			case err == nil:
+				w.attempts = 0
+				w.replayed.Add(inserted)
+				w.publish(data)
+			case !w.reject(data[0], err):
+				return false
+			}
+		}
+		if err = w.buffer.commit(next, lines); err != nil {
+			w.l.Println(err)
+			return false
+		}
+	}
+	return true
+}
+
+// reject the row that failed to insert with the error, it returns true if the row was moved to the rejected file
+func (w *Writer) reject(data *domain.Data, err error) bool {
+	if p, ok := w.d.(pinger); ok && p.Ping() != nil {
+		return false
+	}
+	if w.attempts++; w.attempts < maxAttempts {
+		return false
+	}
+	if werr := w.rejects.write(data); werr != nil {
+		w.l.Println(werr)
+		return false
+	}
+	w.attempts = 0
+	w.rejected.Add(1)
+	w.l.Printf("%s row rejected after %d attempts: %v", domain.PairName(data.FromSymbol, data.ToSymbol),
+		maxAttempts, err)
+	return true
+}
+
+// unspill inserts the spilled rows while the queue is empty
+func (w *Writer) unspill() {
+	for w.spill != nil && len(w.queue) == 0 && w.spill.len() > 0 {
+		data, next, lines, err := w.spill.read(w.batchSize)
+		if err != nil {
+			w.l.Println(err)
+			return
+		}
+		// the rows are moved to the buffer if they weren't inserted, so the spilled ones are committed anyway
+		w.flush(data)
+		if err = w.spill.commit(next, lines); err != nil {
+			w.l.Println(err)
+			return
+		}
+	}
+}
+
+// closeSinks after the last batch is published
+func (w *Writer) closeSinks() {
+	for _, s := range w.sinks {
+		if err := s.Close(); err != nil {
+			w.l.Println(err)
+		}
+	}
+}
+
+func (w *Writer) publish(batch []*domain.Data) {
+	if len(w.sinks) == 0 {
+		return
+	}
+	for _, data := range batch {
+		b, err := json.Marshal(data)
+		if err != nil {
+			w.l.Println(err)
+			continue
+		}
+		key := domain.PairName(data.FromSymbol, data.ToSymbol)
+		for _, s := range w.sinks {
+			if err = s.Publish(key, b); err != nil {
+				w.l.Println(err)
+			}
+		}
+	}
+}
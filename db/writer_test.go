@@ -0,0 +1,263 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"log"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/streamdp/ccd/config"
+	"github.com/streamdp/ccd/db/memory"
+	"github.com/streamdp/ccd/domain"
+	"github.com/streamdp/ccd/hub"
+)
+
+// stalledDb blocks the batch inserts until the release channel is closed
+type stalledDb struct {
+	*memory.Db
+	release chan struct{}
+}
+
+func (d *stalledDb) InsertBatch(data []*domain.Data) (sql.Result, error) {
+	<-d.release
+	return d.Db.InsertBatch(data)
+}
+
+// failingDb fails the inserts while the fail flag is set
+type failingDb struct {
+	*memory.Db
+	fail bool
+}
+
+func (d *failingDb) InsertBatch(data []*domain.Data) (sql.Result, error) {
+	if d.fail {
+		return nil, errors.New("database is down")
+	}
+	return d.Db.InsertBatch(data)
+}
+
+func (d *failingDb) InsertMissing(data []*domain.Data) (int64, error) {
+	if d.fail {
+		return 0, errors.New("database is down")
+	}
+	return d.Db.InsertMissing(data)
+}
+
+// poisonDb rejects the rows with the negative price, it fails everything while it is down
+type poisonDb struct {
+	*memory.Db
+	down bool
+}
+
+func (d *poisonDb) check(data []*domain.Data) error {
+	if d.down {
+		return errors.New("database is down")
+	}
+	for _, row := range data {
+		if row.Price < 0 {
+			return errors.New("price is out of range")
+		}
+	}
+	return nil
+}
+
+func (d *poisonDb) InsertBatch(data []*domain.Data) (sql.Result, error) {
+	if err := d.check(data); err != nil {
+		return nil, err
+	}
+	return d.Db.InsertBatch(data)
+}
+
+func (d *poisonDb) InsertMissing(data []*domain.Data) (int64, error) {
+	if err := d.check(data); err != nil {
+		return 0, err
+	}
+	return d.Db.InsertMissing(data)
+}
+
+func (d *poisonDb) Ping() error {
+	if d.down {
+		return errors.New("database is down")
+	}
+	return nil
+}
+
+// testSink keeps the published keys
+type testSink struct {
+	keys   []string
+	closed bool
+	mu     sync.Mutex
+}
+
+func (s *testSink) Publish(key string, _ []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errors.New("sink is closed")
+	}
+	s.keys = append(s.keys, key)
+	return nil
+}
+
+func (s *testSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+func (s *testSink) published() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.keys)
+}
+
+// newTestWriter with the buffers in the temp dir, the config is restored after the test
+func newTestWriter(t *testing.T, d Database, overflow string, queueSize, batchSize int) *Writer {
+	queue, batch, policy, dir := config.QueueSize, config.BatchSize, config.Overflow, config.SpillDir
+	t.Cleanup(func() {
+		config.QueueSize, config.BatchSize, config.Overflow, config.SpillDir = queue, batch, policy, dir
+	})
+	config.QueueSize, config.BatchSize, config.Overflow, config.SpillDir = queueSize, batchSize, overflow,
+		t.TempDir()
+	w, err := newWriter(d, log.New(io.Discard, "", 0), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return w
+}
+
+func testTick(i int) *domain.Data {
+	return &domain.Data{FromSymbol: "BTC", ToSymbol: "USD", Price: float64(i), LastUpdate: 1705313045000 + int64(i),
+		Provider: "test"}
+}
+
+func TestServeHubNotBlockedByWriter(t *testing.T) {
+	d := &stalledDb{Db: memory.New(), release: make(chan struct{})}
+	w := newTestWriter(t, d, OverflowBlock, 4, 1)
+	h := hub.New()
+	sub := h.SubscribeAll(100)
+	serve(d, h, w, nil)
+
+	// the writer is stuck on the first batch, the queue has room for 4 rows, the next tick is published to the hub
+	// before the pipe waits for room in the queue
+	for i := 0; i < 8; i++ {
+		d.DataPipe() <- testTick(i)
+	}
+	for i := 0; i < 6; i++ {
+		select {
+		case e := <-sub.C():
+			if e.Data.Price != float64(i) {
+				t.Errorf("got tick %v, want %d", e.Data.Price, i)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("the hub got %d ticks, want 6", i)
+		}
+	}
+	if n := len(w.queue); n != 4 {
+		t.Errorf("got %d queued rows, want 4", n)
+	}
+	close(d.release)
+	// the rows left in the pipe and in the queue are stored on close
+	w.Close()
+	rows, err := d.History("BTC", "USD", 0, 0, 0, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 8 {
+		t.Fatalf("got %d stored rows, want 8", len(rows))
+	}
+}
+
+func TestCloseSinksAfterLastBatch(t *testing.T) {
+	d := memory.New()
+	w := newTestWriter(t, d, OverflowBlock, 4, 100)
+	s := &testSink{}
+	w.sinks = []Sink{s}
+	serve(d, hub.New(), w, nil)
+
+	for i := 0; i < 3; i++ {
+		d.DataPipe() <- testTick(i)
+	}
+	w.Close()
+	if n := s.published(); n != 3 {
+		t.Errorf("got %d published rows, want 3", n)
+	}
+	if !s.closed {
+		t.Error("sink is not closed")
+	}
+}
+
+func TestPublishAfterInsert(t *testing.T) {
+	d := &failingDb{Db: memory.New(), fail: true}
+	w := newTestWriter(t, d, OverflowBlock, 4, 2)
+	s := &testSink{}
+	w.sinks = []Sink{s}
+
+	w.flush([]*domain.Data{testTick(0), testTick(1)})
+	if n := s.published(); n != 0 {
+		t.Fatalf("got %d published rows before the insert succeeded", n)
+	}
+	if n := w.buffer.len(); n != 2 {
+		t.Fatalf("got %d buffered rows, want 2", n)
+	}
+	// the buffered rows go first, the new batch waits behind them
+	w.flush([]*domain.Data{testTick(2)})
+	if n := s.published(); n != 0 {
+		t.Fatalf("got %d published rows while the database is down", n)
+	}
+
+	d.fail = false
+	w.replay()
+	if n := s.published(); n != 3 {
+		t.Fatalf("got %d published rows, want 3", n)
+	}
+	w.flush([]*domain.Data{testTick(3)})
+	if n := s.published(); n != 4 {
+		t.Fatalf("got %d published rows, want 4", n)
+	}
+}
+
+func TestRejectPoisonRow(t *testing.T) {
+	d := &poisonDb{Db: memory.New(), down: true}
+	w := newTestWriter(t, d, OverflowBlock, 4, 10)
+	poison := testTick(1)
+	poison.Price = -1
+	w.flush([]*domain.Data{testTick(0), poison, testTick(2)})
+
+	// the row is never rejected while the database is down
+	for i := 0; i < 2*maxAttempts; i++ {
+		w.replay()
+	}
+	if n := w.rejects.len(); n != 0 {
+		t.Fatalf("got %d rejected rows while the database is down", n)
+	}
+	if n := w.buffer.len(); n != 3 {
+		t.Fatalf("got %d buffered rows, want 3", n)
+	}
+
+	d.down = false
+	for i := 1; i < maxAttempts; i++ {
+		w.replay()
+	}
+	if n := w.buffer.len(); n != 2 {
+		t.Fatalf("got %d buffered rows before the last attempt, want 2", n)
+	}
+	w.replay()
+	if n := w.buffer.len(); n != 0 {
+		t.Errorf("got %d buffered rows, want 0", n)
+	}
+	if n := w.rejects.len(); n != 1 {
+		t.Errorf("got %d rejected rows, want 1", n)
+	}
+	rows, err := d.History("BTC", "USD", 0, 0, 0, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Errorf("got %d stored rows, want 2", len(rows))
+	}
+}
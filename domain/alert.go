@@ -0,0 +1,42 @@
+package domain
+
+// Alert conditions
+const (
+	AlertCrosses = "crosses" // the price crosses the value in any direction
+	AlertAbove   = "above"   // the price crosses the value upwards
+	AlertBelow   = "below"   // the price crosses the value downwards
+	AlertChange  = "change"  // the price moves more than the value percent within the window
+)
+
+// Alert rule for the currencies pair, matches are delivered to the url signed with the secret
+type Alert struct {
+	Id        int64   `json:"id"`
+	From      string  `json:"fsym"`
+	To        string  `json:"tsym"`
+	Condition string  `json:"condition"`
+	Value     float64 `json:"value"`
+	Window    int64   `json:"window,omitempty"`
+	Url       string  `json:"url"`
+	Secret    string  `json:"-"`
+	Enabled   bool    `json:"enabled"`
+}
+
+// AlertEvent is the payload of the alert delivery
+type AlertEvent struct {
+	Alert    *Alert  `json:"alert"`
+	Data     *Data   `json:"data"`
+	Previous float64 `json:"previous"`
+	Change   float64 `json:"change,omitempty"`
+	Time     int64   `json:"time"`
+}
+
+// Delivery log record of the one webhook delivery attempt
+type Delivery struct {
+	Id        int64  `json:"id"`
+	AlertId   int64  `json:"alert_id"`
+	Url       string `json:"url"`
+	Attempt   int    `json:"attempt"`
+	Status    int    `json:"status"`
+	Error     string `json:"error,omitempty"`
+	CreatedAt int64  `json:"created_at"`
+}
@@ -0,0 +1,22 @@
+package domain
+
+// Candle OHLCV values of the stored data aggregated over the time bucket. Providers don't send per-trade volume,
+// so Volume is the 24h volume reported by the last row in the bucket.
+type Candle struct {
+	Time   int64   `json:"time"`
+	Open   float64 `json:"open"`
+	High   float64 `json:"high"`
+	Low    float64 `json:"low"`
+	Close  float64 `json:"close"`
+	Volume float64 `json:"volume"`
+	Ticks  int64   `json:"ticks"`
+}
+
+// CandleIntervals supported candle intervals in seconds
+var CandleIntervals = map[string]int64{
+	"1m":  60,
+	"5m":  5 * 60,
+	"15m": 15 * 60,
+	"1h":  60 * 60,
+	"1d":  24 * 60 * 60,
+}
@@ -0,0 +1,18 @@
+package domain
+
+// Consensus volume weighted price of the several data providers
+type Consensus struct {
+	FromSymbol string             `json:"from_sym"`
+	ToSymbol   string             `json:"to_sym"`
+	Price      float64            `json:"price"`
+	Sources    []*ConsensusSource `json:"sources"`
+}
+
+// ConsensusSource price and 24h volume reported by the data provider, outliers are not included in the consensus price
+type ConsensusSource struct {
+	Provider   string  `json:"provider"`
+	Price      float64 `json:"price"`
+	Volume     float64 `json:"volume"`
+	LastUpdate int64   `json:"last_update"`
+	Outlier    bool    `json:"outlier"`
+}
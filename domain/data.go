@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type Data struct {
 	Id              int64   `json:"id" db:"_id"`
 	FromSymbol      string  `json:"from_sym" db:"fromSym"`
@@ -15,6 +17,16 @@ type Data struct {
 	MktCap          float64 `json:"mkt_cap" db:"mktcap"`
 	LastUpdate      int64   `json:"last_update" db:"lastupdate"`
 	DisplayDataRaw  string  `json:"display_data_raw" db:"displaydataraw"`
+	Provider        string  `json:"provider" db:"provider"`
+}
+
+// UpdatedAt return the time of the last update, LastUpdate could be in seconds or in milliseconds depending on the
+// data provider
+func (d *Data) UpdatedAt() time.Time {
+	if d.LastUpdate > 9999999999 {
+		return time.UnixMilli(d.LastUpdate)
+	}
+	return time.Unix(d.LastUpdate, 0)
 }
 
 // Raw structure for easily json serialization
@@ -33,3 +45,9 @@ type Raw struct {
 	MktCap          float64 `json:"mkt_cap"`
 	LastUpdate      int64   `json:"last_update"`
 }
+
+// History page of the stored data with the cursor to request the next page
+type History struct {
+	Data       []*Data `json:"data"`
+	NextCursor int64   `json:"next_cursor"`
+}
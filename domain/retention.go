@@ -0,0 +1,33 @@
+package domain
+
+// RetentionPolicy of the currencies pair, the days the data is kept at every resolution, zero keeps it forever
+type RetentionPolicy struct {
+	Pair   string `json:"pair"`   // "FROM:TO" or "*" for the pairs without their own policy
+	Raw    int    `json:"raw"`    // days the raw ticks are kept before they are rolled up to the minute rows
+	Minute int    `json:"minute"` // days the minute rows are kept before they are rolled up to the hourly rows
+	Hour   int    `json:"hour"`   // days the hourly rows are kept before they are deleted
+}
+
+// RetentionRun result of the retention job run
+type RetentionRun struct {
+	Started  int64            `json:"started"`
+	Duration int64            `json:"duration_ms"`
+	Deleted  int64            `json:"deleted"`
+	Pairs    []*RetentionPair `json:"pairs"`
+}
+
+// RetentionPair result of the retention policy applied to the currencies pair
+type RetentionPair struct {
+	Pair    string `json:"pair"`
+	Deleted int64  `json:"deleted"`
+	Error   string `json:"error,omitempty"`
+}
+
+// RetentionStatus of the retention job
+type RetentionStatus struct {
+	Policies []*RetentionPolicy `json:"policies"`
+	Interval int64              `json:"interval_min"`
+	Running  bool               `json:"running"`
+	Last     *RetentionRun      `json:"last,omitempty"`
+	Error    string             `json:"error,omitempty"` // of the last run
+}
@@ -11,6 +11,11 @@ type Subscribe struct {
 }
 type Subscribes map[string]*Subscribe
 
+// PairName return FROM:TO name of the currencies pair
+func PairName(from, to string) string {
+	return strings.ToUpper(from + ":" + to)
+}
+
 func NewSubscribe(from, to string, id int64) *Subscribe {
 	return &Subscribe{
 		From: strings.ToUpper(from),
@@ -0,0 +1,21 @@
+package domain
+
+// Webhook registration, every tick of the selected pairs (or of all pairs if none selected) is posted to the url in
+// batches collected within the window
+type Webhook struct {
+	Id       int64    `json:"id"`
+	Pairs    []string `json:"pairs"`
+	Url      string   `json:"url"`
+	Secret   string   `json:"-"`
+	Window   int64    `json:"window"`
+	Enabled  bool     `json:"enabled"`
+	Failures int      `json:"failures"`
+}
+
+// WebhookBatch is the payload of the webhook delivery
+type WebhookBatch struct {
+	WebhookId int64   `json:"webhook_id"`
+	Data      []*Data `json:"data"`
+	Dropped   int64   `json:"dropped,omitempty"`
+	Time      int64   `json:"time"`
+}
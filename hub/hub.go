@@ -0,0 +1,162 @@
+package hub
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/streamdp/ccd/domain"
+)
+
+// historySize is the number of the last events the hub keeps to resume the streams of the reconnecting peers
+const historySize = 10000
+
+// Event is the published data with its id, the ids grow monotonically and start from the unix time in microseconds
+// when the hub was created, so the ids of the restarted hub are greater than the ones of the previous run
+type Event struct {
+	Id   int64
+	Data *domain.Data
+}
+
+// Hub fans out the data received from the data providers to the subscribers in-process
+type Hub struct {
+	subs map[*Subscriber]struct{}
+	// last published event id and the id of the last event dropped from the history
+	seq, floor int64
+	history    []Event
+	next       int
+	mu         sync.RWMutex
+}
+
+// Subscriber receives data of the selected currencies pairs (or of all pairs) through the buffered channel, data is
+// dropped if the subscriber doesn't keep up, so slow consumers never block the data providers
+type Subscriber struct {
+	c       chan Event
+	all     bool
+	pairs   map[string]struct{}
+	dropped int64
+	mu      sync.RWMutex
+}
+
+// New init empty hub
+func New() *Hub {
+	seq := time.Now().UnixMicro()
+	return &Hub{
+		subs:    map[*Subscriber]struct{}{},
+		seq:     seq,
+		floor:   seq,
+		history: make([]Event, 0, historySize),
+	}
+}
+
+// Subscribe new subscriber with the selected buffer size, it receives nothing until pairs are added
+func (h *Hub) Subscribe(size int) *Subscriber {
+	return h.subscribe(size, false)
+}
+
+// SubscribeAll new subscriber with the selected buffer size, it receives data of all currencies pairs
+func (h *Hub) SubscribeAll(size int) *Subscriber {
+	return h.subscribe(size, true)
+}
+
+func (h *Hub) subscribe(size int, all bool) *Subscriber {
+	s := &Subscriber{
+		c:     make(chan Event, size),
+		all:   all,
+		pairs: map[string]struct{}{},
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subs[s] = struct{}{}
+	return s
+}
+
+// Unsubscribe remove the subscriber from the hub and close its channel
+func (h *Hub) Unsubscribe(s *Subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.subs[s]; ok {
+		delete(h.subs, s)
+		close(s.c)
+	}
+}
+
+// Publish data to all subscribers of its currencies pair
+func (h *Hub) Publish(d *domain.Data) {
+	if d == nil {
+		return
+	}
+	pair := domain.PairName(d.FromSymbol, d.ToSymbol)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.seq++; h.seq < time.Now().UnixMicro() {
+		// keep the ids close to the clock, so they stay greater than the ids of the previous run after a restart
+		h.seq = time.Now().UnixMicro()
+	}
+	e := Event{Id: h.seq, Data: d}
+	if len(h.history) < historySize {
+		h.history = append(h.history, e)
+	} else {
+		h.floor = h.history[h.next].Id
+		h.history[h.next] = e
+		h.next = (h.next + 1) % historySize
+	}
+	for s := range h.subs {
+		if !s.match(pair) {
+			continue
+		}
+		select {
+		case s.c <- e:
+		default:
+			atomic.AddInt64(&s.dropped, 1)
+		}
+	}
+}
+
+// Since return up to limit events published after the event with the id, oldest first. It returns false if some
+// of the events after the id are not kept in the history anymore or were published before the hub was created.
+func (h *Hub) Since(id int64, limit int) (events []Event, complete bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	// the history is ordered starting from the next position
+	for i := 0; i < len(h.history) && len(events) < limit; i++ {
+		if e := h.history[(h.next+i)%len(h.history)]; e.Id > id {
+			events = append(events, e)
+		}
+	}
+	return events, id >= h.floor
+}
+
+// C return the channel to receive events from
+func (s *Subscriber) C() <-chan Event {
+	return s.c
+}
+
+// Add the currencies pair to the subscriber
+func (s *Subscriber) Add(from, to string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pairs[domain.PairName(from, to)] = struct{}{}
+}
+
+// Remove the currencies pair from the subscriber
+func (s *Subscriber) Remove(from, to string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.pairs, domain.PairName(from, to))
+}
+
+// Dropped return the number of data items dropped because the subscriber's buffer was full
+func (s *Subscriber) Dropped() int64 {
+	return atomic.LoadInt64(&s.dropped)
+}
+
+func (s *Subscriber) match(pair string) bool {
+	if s.all {
+		return true
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.pairs[pair]
+	return ok
+}
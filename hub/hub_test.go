@@ -0,0 +1,56 @@
+package hub
+
+import (
+	"testing"
+
+	"github.com/streamdp/ccd/domain"
+)
+
+func TestPublishIds(t *testing.T) {
+	h := New()
+	btc := h.Subscribe(10)
+	btc.Add("BTC", "USD")
+	all := h.SubscribeAll(10)
+	h.Publish(&domain.Data{FromSymbol: "BTC", ToSymbol: "USD"})
+	h.Publish(&domain.Data{FromSymbol: "ETH", ToSymbol: "USD"})
+	h.Publish(&domain.Data{FromSymbol: "BTC", ToSymbol: "USD"})
+
+	first, second := <-btc.C(), <-btc.C()
+	if first.Id <= h.floor || second.Id <= first.Id || len(btc.C()) != 0 {
+		t.Errorf("got ids %d, %d after %d, want growing ids of BTC:USD only", first.Id, second.Id, h.floor)
+	}
+	var last int64
+	for i := 0; i < 3; i++ {
+		e := <-all.C()
+		if e.Id <= last {
+			t.Errorf("got id %d after %d", e.Id, last)
+		}
+		last = e.Id
+	}
+}
+
+func TestSince(t *testing.T) {
+	h := New()
+	start := h.seq
+	for i := 0; i < historySize+10; i++ {
+		h.Publish(&domain.Data{FromSymbol: "BTC", ToSymbol: "USD", Price: float64(i)})
+	}
+	events, complete := h.Since(start, 5)
+	if complete || len(events) != 5 || events[0].Data.Price != 10 {
+		t.Errorf("got %d events from %v, complete %v, want 5 events from 10, incomplete", len(events),
+			events[0].Data.Price, complete)
+	}
+	events, complete = h.Since(events[4].Id, historySize)
+	if !complete || len(events) != historySize-5 || events[0].Data.Price != 15 {
+		t.Errorf("got %d events, complete %v, want %d events from 15, complete", len(events), complete,
+			historySize-5)
+	}
+	for i := 1; i < len(events); i++ {
+		if events[i].Id <= events[i-1].Id {
+			t.Fatalf("event %d id %d is not greater than %d", i, events[i].Id, events[i-1].Id)
+		}
+	}
+	if events, complete = h.Since(events[len(events)-1].Id, 10); !complete || len(events) != 0 {
+		t.Errorf("got %d events, complete %v after the last one", len(events), complete)
+	}
+}
@@ -1,31 +1,74 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/streamdp/ccd/alerts"
 	"github.com/streamdp/ccd/clients"
+	"github.com/streamdp/ccd/clients/binance"
+	"github.com/streamdp/ccd/clients/coinbase"
 	"github.com/streamdp/ccd/clients/cryptocompare"
+	"github.com/streamdp/ccd/clients/generic"
 	"github.com/streamdp/ccd/clients/huobi"
+	"github.com/streamdp/ccd/clients/kraken"
+	"github.com/streamdp/ccd/clients/recorder"
+	"github.com/streamdp/ccd/clients/replay"
 	"github.com/streamdp/ccd/config"
 	"github.com/streamdp/ccd/db"
 	"github.com/streamdp/ccd/db/redis"
+	"github.com/streamdp/ccd/hub"
 	"github.com/streamdp/ccd/repos"
+	"github.com/streamdp/ccd/retention"
 	"github.com/streamdp/ccd/router"
+	"github.com/streamdp/ccd/rpc"
+	"github.com/streamdp/ccd/webhook"
 )
 
+// shutdownTimeout limits the time the running requests have to finish on exit
+const shutdownTimeout = 5 * time.Second
+
 func main() {
 	l := log.New(os.Stderr, "CCD:", log.LstdFlags)
 
 	config.ParseFlags()
 	gin.SetMode(config.RunMode)
 
+	h := hub.New()
+
 	d, err := db.Connect(l)
 	if err != nil {
 		l.Fatalln(err)
 	}
+	// the migrations are reverted before the writer starts, so nothing is written to the reverted schema
+	if config.Migrate == db.MigrateDown {
+		reverted, err := db.Revert(d, config.MigrateSteps)
+		if err != nil {
+			l.Fatalln(err)
+		}
+		l.Printf("reverted %d database migrations", reverted)
+		return
+	}
+
+	var rec *recorder.Recorder
+	if config.RecordDir != "" {
+		if rec, err = recorder.New(config.RecordDir, int64(config.RecordMaxSize)<<20, l); err != nil {
+			l.Fatalln(err)
+		}
+	}
+
+	w, err := db.Serve(d, l, h, rec)
+	if err != nil {
+		l.Fatalln(err)
+	}
 
 	s := newSessionStore(d, l)
 
@@ -34,46 +77,82 @@ func main() {
 		l.Fatalln(err)
 	}
 
-	r, err := initRestClient()
+	pr, err := initProviders(d, l, rec)
 	if err != nil {
 		l.Fatalln(err)
 	}
 
-	w, err := initWsClient(d, l)
+	p := clients.NewPuller(pr, l, s, d.DataPipe())
+	if err = p.RestoreLastSession(); err != nil {
+		l.Println(fmt.Errorf("error restoring last session: %w", err))
+	}
+
+	ae, err := alerts.NewEngine(d, l, h)
 	if err != nil {
 		l.Fatalln(err)
 	}
+	ae.Run()
 
-	p := clients.NewPuller(r, l, s, d.DataPipe())
-	if err = p.RestoreLastSession(); err != nil {
-		l.Println(fmt.Errorf("error restoring last session: %w", err))
+	ds, err := webhook.NewDispatcher(d, l, h)
+	if err != nil {
+		l.Fatalln(err)
 	}
+	ds.Run()
 
-	e := gin.Default()
-	if err = router.InitRouter(e, d, l, sr, r, w, p); err != nil {
+	rj, err := retention.NewJob(d, l)
+	if err != nil {
 		l.Fatalln(err)
 	}
-	if err = e.Run(config.Port); err != nil {
+	rj.Run()
+
+	var g *rpc.Server
+	if config.GrpcPort != "" {
+		g = rpc.NewServer(l, d, sr, pr, p, h)
+		go func() {
+			if err := g.Serve(config.GrpcPort); err != nil {
+				l.Fatalln(err)
+			}
+		}()
+	}
+
+	e := gin.Default()
+	if err = router.InitRouter(e, d, l, sr, pr, p, h, ae, ds, rj); err != nil {
 		l.Fatalln(err)
 	}
-}
+	srv := &http.Server{Addr: config.Port, Handler: e}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			l.Fatalln(err)
+		}
+	}()
 
-func initRestClient() (r clients.RestClient, err error) {
-	switch config.DataProvider {
-	case "huobi":
-		return huobi.Init()
-	default:
-		return cryptocompare.Init()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+	l.Println("shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err = srv.Shutdown(shutdownCtx); err != nil {
+		l.Println(err)
 	}
+	if g != nil {
+		g.Stop(shutdownCtx)
+	}
+	// the ticks are recorded until the writer stops reading the data pipe
+	w.Close()
+	rec.Close()
 }
 
-func initWsClient(d db.Database, l *log.Logger) (w clients.WsClient, err error) {
-	switch config.DataProvider {
-	case "huobi":
-		return huobi.InitWs(d.DataPipe(), l)
-	default:
-		return cryptocompare.InitWs(d.DataPipe(), l)
-	}
+func initProviders(d db.Database, l *log.Logger, rec *recorder.Recorder) (*clients.Providers, error) {
+	r := clients.NewRegistry(rec)
+	r.Register(cryptocompare.Name, cryptocompare.Init, cryptocompare.InitWs)
+	r.Register(huobi.Name, huobi.Init, huobi.InitWs)
+	r.Register(binance.Name, binance.Init, binance.InitWs)
+	r.Register(kraken.Name, kraken.Init, kraken.InitWs)
+	r.Register(coinbase.Name, coinbase.Init, coinbase.InitWs)
+	r.Register(generic.Name, generic.Init, nil)
+	r.Register(replay.Name, replay.Init, replay.InitWs)
+	return r.Build(config.DataProviders(), d.DataPipe(), l)
 }
 
 func newSessionStore(d db.Database, l *log.Logger) (s db.Session) {
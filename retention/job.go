@@ -0,0 +1,229 @@
+package retention
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/streamdp/ccd/config"
+	"github.com/streamdp/ccd/db"
+	"github.com/streamdp/ccd/domain"
+)
+
+// ErrRunning is returned when the job is triggered while it is running
+var ErrRunning = errors.New("retention job is already running")
+
+// step of the policy, the rows older than the days are rolled up to the open, high, low and close rows of every bucket
+// (in seconds), zero bucket deletes them
+type step struct {
+	days   int
+	bucket int64
+}
+
+// compressor is implemented by the databases that could compress the old data, the compressed rows can't be
+// downsampled
+type compressor interface {
+	CompressAfter() (days int, err error)
+}
+
+// Job applies the retention policies to the stored data periodically: the raw ticks are rolled up to the open, high,
+// low and close rows of every minute, the minute rows to the ones of every hour and the hourly rows are deleted
+type Job struct {
+	db       db.Database
+	l        *log.Logger
+	policies []*domain.RetentionPolicy
+	interval time.Duration
+	last     *domain.RetentionRun
+	err      error // of the last run
+	running  bool
+	mu       sync.Mutex
+}
+
+// NewJob init retention job with the policies selected by the "-retention" flag
+func NewJob(d db.Database, l *log.Logger) (*Job, error) {
+	policies, err := ParsePolicies(config.Retention)
+	if err != nil {
+		return nil, err
+	}
+	if len(policies) > 0 {
+		if config.RetentionInterval <= 0 {
+			return nil, fmt.Errorf("retention interval should be positive, got %d", config.RetentionInterval)
+		}
+		if err = checkCompression(d, policies); err != nil {
+			return nil, err
+		}
+	}
+	return &Job{
+		db:       d,
+		l:        l,
+		policies: policies,
+		interval: time.Duration(config.RetentionInterval) * time.Minute,
+	}, nil
+}
+
+// Run the job in the background if there are any policies
+func (j *Job) Run() {
+	if len(j.policies) == 0 {
+		return
+	}
+	go func() {
+		t := time.NewTicker(j.interval)
+		defer t.Stop()
+		for range t.C {
+			if _, err := j.Trigger(); err != nil && !errors.Is(err, ErrRunning) {
+				j.l.Println(fmt.Errorf("retention job failed: %w", err))
+			}
+		}
+	}()
+}
+
+// checkCompression of the database, the days of the policies should be shorter than the days the data is compressed
+// after, otherwise the job would delete the compressed rows
+func checkCompression(d db.Database, policies []*domain.RetentionPolicy) error {
+	c, ok := d.(compressor)
+	if !ok {
+		return nil
+	}
+	after, err := c.CompressAfter()
+	if err != nil || after == 0 {
+		return err
+	}
+	for _, p := range policies {
+		for _, days := range []int{p.Raw, p.Minute, p.Hour} {
+			if days >= after {
+				return fmt.Errorf("retention policy of %s: the data is compressed after %d days, keep it for fewer"+
+					" days, raise -tscompress or drop the old data with -tsretention", p.Pair, after)
+			}
+		}
+	}
+	return nil
+}
+
+// Status of the job with the result of the last run
+func (j *Job) Status() *domain.RetentionStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	s := &domain.RetentionStatus{
+		Interval: int64(j.interval / time.Minute),
+		Running:  j.running,
+		Last:     j.last,
+	}
+	if j.err != nil {
+		s.Error = j.err.Error()
+	}
+	for _, p := range j.policies {
+		c := *p
+		s.Policies = append(s.Policies, &c)
+	}
+	return s
+}
+
+// Start the job run in the background, the result is reported by the status
+func (j *Job) Start() error {
+	if !j.begin() {
+		return ErrRunning
+	}
+	go func() {
+		if _, err := j.finish(j.run(time.Now())); err != nil {
+			j.l.Println(fmt.Errorf("retention job failed: %w", err))
+		}
+	}()
+	return nil
+}
+
+// Trigger the job run now and wait for the result, the errors of the pairs are reported in the result
+func (j *Job) Trigger() (*domain.RetentionRun, error) {
+	if !j.begin() {
+		return nil, ErrRunning
+	}
+	return j.finish(j.run(time.Now()))
+}
+
+// begin the run if the job is not running
+func (j *Job) begin() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.running {
+		return false
+	}
+	j.running = true
+	return true
+}
+
+// finish the run and keep its result
+func (j *Job) finish(run *domain.RetentionRun, err error) (*domain.RetentionRun, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.running, j.err = false, err
+	if err != nil {
+		return nil, err
+	}
+	j.last = run
+	return run, nil
+}
+
+func (j *Job) run(now time.Time) (*domain.RetentionRun, error) {
+	pairs, err := j.pairs()
+	if err != nil {
+		return nil, err
+	}
+	run := &domain.RetentionRun{Started: now.Unix()}
+	for _, pair := range pairs {
+		p := j.policy(pair)
+		if p == nil {
+			continue
+		}
+		r := &domain.RetentionPair{Pair: pair}
+		symbols := strings.Split(pair, ":")
+		for _, s := range []step{{p.Raw, 60}, {p.Minute, 60 * 60}, {p.Hour, 0}} {
+			if s.days == 0 {
+				break
+			}
+			deleted, err := j.db.Downsample(symbols[0], symbols[1], now.AddDate(0, 0, -s.days).Unix(), s.bucket)
+			if err != nil {
+				r.Error = err.Error()
+				j.l.Println(fmt.Errorf("failed to apply retention policy to %s: %w", pair, err))
+				break
+			}
+			r.Deleted += deleted
+		}
+		run.Deleted += r.Deleted
+		run.Pairs = append(run.Pairs, r)
+	}
+	run.Duration = time.Since(now).Milliseconds()
+	return run, nil
+}
+
+// pairs with the stored data and the pairs of the own policies
+func (j *Job) pairs() ([]string, error) {
+	pairs, err := j.db.Pairs()
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]struct{}, len(pairs))
+	for _, pair := range pairs {
+		seen[pair] = struct{}{}
+	}
+	for _, p := range j.policies {
+		if _, ok := seen[p.Pair]; !ok && p.Pair != DefaultPair {
+			pairs = append(pairs, p.Pair)
+		}
+	}
+	return pairs, nil
+}
+
+// policy of the pair, the default one if the pair has no own policy, or nil if there is no default one
+func (j *Job) policy(pair string) (policy *domain.RetentionPolicy) {
+	for _, p := range j.policies {
+		switch p.Pair {
+		case pair:
+			return p
+		case DefaultPair:
+			policy = p
+		}
+	}
+	return policy
+}
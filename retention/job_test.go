@@ -0,0 +1,86 @@
+package retention
+
+import (
+	"errors"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/streamdp/ccd/config"
+	"github.com/streamdp/ccd/db/memory"
+)
+
+// testDb compresses the data after the days and blocks the runs until released
+type testDb struct {
+	*memory.Db
+	days    int
+	release chan struct{}
+}
+
+func (d *testDb) CompressAfter() (int, error) {
+	return d.days, nil
+}
+
+func (d *testDb) Pairs() ([]string, error) {
+	<-d.release
+	return d.Db.Pairs()
+}
+
+func newTestJob(t *testing.T, d *testDb, retention string, interval int) (*Job, error) {
+	r, i := config.Retention, config.RetentionInterval
+	t.Cleanup(func() {
+		config.Retention, config.RetentionInterval = r, i
+	})
+	config.Retention, config.RetentionInterval = retention, interval
+	return NewJob(d, log.New(io.Discard, "", 0))
+}
+
+func TestNewJob(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		retention string
+		interval  int
+		days      int
+		wantErr   bool
+	}{
+		{name: "disabled", interval: 0},
+		{name: "interval", retention: "*=7,90,0", interval: 0, wantErr: true},
+		{name: "uncompressed", retention: "*=7,90,0", interval: 60},
+		{name: "compressed later", retention: "*=1,3,6", interval: 60, days: 7},
+		{name: "compressed raw", retention: "*=7,90,0", interval: 60, days: 7, wantErr: true},
+		{name: "compressed hours", retention: "*=1,3,7", interval: 60, days: 7, wantErr: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTestJob(t, &testDb{Db: memory.New(), days: tt.days}, tt.retention, tt.interval)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStart(t *testing.T) {
+	d := &testDb{Db: memory.New(), release: make(chan struct{})}
+	j, err := newTestJob(t, d, "*=7,90,0", 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = j.Start(); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if err = j.Start(); !errors.Is(err, ErrRunning) {
+		t.Errorf("got error %v, want %v", err, ErrRunning)
+	}
+	if s := j.Status(); !s.Running || s.Last != nil {
+		t.Errorf("got status %+v, want running without the last run", s)
+	}
+	close(d.release)
+	deadline := time.Now().Add(time.Second)
+	for j.Status().Running && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if s := j.Status(); s.Running || s.Last == nil || s.Error != "" {
+		t.Errorf("got status %+v, want the last run finished", s)
+	}
+}
@@ -0,0 +1,60 @@
+package retention
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/streamdp/ccd/domain"
+)
+
+// DefaultPair of the policy applied to the pairs without their own policy
+const DefaultPair = "*"
+
+// ParsePolicies like "*=7,90,0;BTC:USD=30,365,0", the days the raw ticks, the minute and the hourly rows are kept
+func ParsePolicies(s string) (policies []*domain.RetentionPolicy, err error) {
+	seen := map[string]struct{}{}
+	for _, item := range strings.Split(s, ";") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		parts := strings.SplitN(item, "=", 2)
+		days := strings.Split(parts[len(parts)-1], ",")
+		if len(parts) != 2 || len(days) != 3 {
+			return nil, fmt.Errorf("retention policy %q should look like \"FROM:TO=raw,minute,hour\"", item)
+		}
+		p := &domain.RetentionPolicy{Pair: strings.ToUpper(strings.TrimSpace(parts[0]))}
+		for i, v := range []*int{&p.Raw, &p.Minute, &p.Hour} {
+			if *v, err = strconv.Atoi(strings.TrimSpace(days[i])); err != nil || *v < 0 {
+				return nil, fmt.Errorf("retention policy %q: days should be non-negative numbers", item)
+			}
+		}
+		if err = validate(p); err != nil {
+			return nil, err
+		}
+		if _, ok := seen[p.Pair]; ok {
+			return nil, fmt.Errorf("duplicate retention policy of %s", p.Pair)
+		}
+		seen[p.Pair] = struct{}{}
+		policies = append(policies, p)
+	}
+	return policies, nil
+}
+
+// validate the policy, every resolution is kept not shorter than the previous one and the data kept forever at some
+// resolution is never rolled up further
+func validate(p *domain.RetentionPolicy) error {
+	if p.Pair != DefaultPair && len(strings.Split(p.Pair, ":")) != 2 {
+		return fmt.Errorf("retention policy pair %q should look like FROM:TO or %s", p.Pair, DefaultPair)
+	}
+	days := []int{p.Raw, p.Minute, p.Hour}
+	for i := 1; i < len(days); i++ {
+		switch {
+		case days[i-1] == 0 && days[i] != 0:
+			return fmt.Errorf("retention policy of %s: the data kept forever can't be rolled up", p.Pair)
+		case days[i] != 0 && days[i] < days[i-1]:
+			return fmt.Errorf("retention policy of %s: the days should not decrease", p.Pair)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package router
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/streamdp/ccd/router/handlers"
+)
+
+// AdminOnly pass the requests with the "Authorization: Bearer <token>" header of the admin token
+func AdminOnly(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		got, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			res := handlers.Result{}
+			res.UpdateAllFields(http.StatusUnauthorized, "admin token is required", nil)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, res)
+			return
+		}
+		c.Next()
+	}
+}
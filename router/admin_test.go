@@ -0,0 +1,37 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAdminOnly(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	e := gin.New()
+	e.GET("/admin", AdminOnly("s3cr3t"), SendOK)
+	for _, tt := range []struct {
+		name   string
+		header string
+		want   int
+	}{
+		{name: "token", header: "Bearer s3cr3t", want: http.StatusOK},
+		{name: "wrong token", header: "Bearer s3cr3", want: http.StatusUnauthorized},
+		{name: "no bearer", header: "s3cr3t", want: http.StatusUnauthorized},
+		{name: "no token", want: http.StatusUnauthorized},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			e.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("got status %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ type Result struct {
 	Code    int         `json:"code"`
 	Message string      `json:"msg"`
 	Data    interface{} `json:"data"`
+	Meta    interface{} `json:"meta,omitempty"`
 }
 
 // UpdateAllFields in Result and return it
@@ -20,3 +21,9 @@ func (res *Result) UpdateDataField(data interface{}) *Result {
 	res.Data = data
 	return res
 }
+
+// UpdateMetaField in Result and return it
+func (res *Result) UpdateMetaField(meta interface{}) *Result {
+	res.Meta = meta
+	return res
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/streamdp/ccd/config"
+	"github.com/streamdp/ccd/db"
 )
 
 // SendHTML show a beautiful page with small intro and instruction
@@ -21,3 +22,9 @@ func SendHTML(c *gin.Context) {
 func SendOK(c *gin.Context) {
 	c.JSON(http.StatusOK, nil)
 }
+
+// SendMetrics of the writer in the expvar format, the other published variables like the command line and the memory
+// stats are not exposed
+func SendMetrics(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json; charset=utf-8", []byte(`{"writer": `+db.Metrics.String()+"}"))
+}
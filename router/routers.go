@@ -6,13 +6,19 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
+	"github.com/streamdp/ccd/alerts"
 	"github.com/streamdp/ccd/clients"
+	"github.com/streamdp/ccd/config"
 	"github.com/streamdp/ccd/db"
+	"github.com/streamdp/ccd/hub"
 	"github.com/streamdp/ccd/repos"
+	"github.com/streamdp/ccd/retention"
 	"github.com/streamdp/ccd/router/handlers"
 	v1 "github.com/streamdp/ccd/router/v1"
+	"github.com/streamdp/ccd/router/v1/sse"
 	"github.com/streamdp/ccd/router/v1/validators"
 	"github.com/streamdp/ccd/router/v1/ws"
+	"github.com/streamdp/ccd/webhook"
 )
 
 // InitRouter basic work on setting up the application, declare endpoints, register our custom validation functions
@@ -21,12 +27,17 @@ func InitRouter(
 	d db.Database,
 	l *log.Logger,
 	sr *repos.SymbolRepo,
-	r clients.RestClient,
-	w clients.WsClient,
+	pr *clients.Providers,
 	p clients.RestApiPuller,
+	h *hub.Hub,
+	ae *alerts.Engine,
+	ds *webhook.Dispatcher,
+	rj *retention.Job,
 ) (err error) {
 	// health checks
 	e.GET("/healthz", SendOK)
+	// writer metrics
+	e.GET("/debug/vars", SendMetrics)
 
 	// serve web page
 	e.LoadHTMLFiles("site/index.tmpl")
@@ -41,12 +52,15 @@ func InitRouter(
 		apiV1.GET("/collect/add", handlers.GinHandler(v1.AddWorker(p)))
 		apiV1.GET("/collect/remove", handlers.GinHandler(v1.RemoveWorker(p)))
 		apiV1.GET("/collect/update", handlers.GinHandler(v1.UpdateWorker(p)))
-		apiV1.GET("/collect/status", handlers.GinHandler(v1.PullingStatus(p, w)))
+		apiV1.GET("/collect/status", handlers.GinHandler(v1.PullingStatus(p, pr)))
 		apiV1.GET("/symbols/add", handlers.GinHandler(v1.AddSymbol(sr)))
 		apiV1.GET("/symbols/update", handlers.GinHandler(v1.UpdateSymbol(sr)))
 		apiV1.GET("/symbols/remove", handlers.GinHandler(v1.RemoveSymbol(sr)))
-		apiV1.GET("/price", handlers.GinHandler(v1.Price(r, d)))
-		apiV1.GET("/ws", ws.HandleWs(r, l, d))
+		apiV1.GET("/price", handlers.GinHandler(v1.Price(pr, d)))
+		apiV1.GET("/history", handlers.GinHandler(v1.History(d)))
+		apiV1.GET("/candles", handlers.GinHandler(v1.Candles(d)))
+		apiV1.GET("/ws", ws.HandleWs(pr, sr, l, d, h))
+		apiV1.GET("/stream", sse.HandleStream(l, d, sr, h))
 
 		apiV1.POST("/collect", handlers.GinHandler(v1.AddWorker(p)))
 		apiV1.PUT("/collect", handlers.GinHandler(v1.UpdateWorker(p)))
@@ -54,18 +68,44 @@ func InitRouter(
 		apiV1.POST("/symbols", handlers.GinHandler(v1.AddSymbol(sr)))
 		apiV1.PUT("/symbols", handlers.GinHandler(v1.UpdateSymbol(sr)))
 		apiV1.DELETE("/symbols", handlers.GinHandler(v1.RemoveSymbol(sr)))
-		apiV1.POST("/price", handlers.GinHandler(v1.Price(r, d)))
-		if w != nil {
-			apiV1.POST("/ws/subscribe", handlers.GinHandler(v1.Subscribe(w)))
-			apiV1.GET("/ws/subscribe", handlers.GinHandler(v1.Subscribe(w)))
-			apiV1.POST("/ws/unsubscribe", handlers.GinHandler(v1.Unsubscribe(w)))
-			apiV1.GET("/ws/unsubscribe", handlers.GinHandler(v1.Unsubscribe(w)))
-		}
+		apiV1.POST("/price", handlers.GinHandler(v1.Price(pr, d)))
+		apiV1.POST("/history", handlers.GinHandler(v1.History(d)))
+		apiV1.POST("/candles", handlers.GinHandler(v1.Candles(d)))
+
+		apiV1.GET("/alerts", handlers.GinHandler(v1.ListAlerts(ae)))
+		apiV1.GET("/alerts/:id", handlers.GinHandler(v1.GetAlert(ae)))
+		apiV1.GET("/alerts/:id/deliveries", handlers.GinHandler(v1.AlertDeliveries(ae)))
+		apiV1.POST("/alerts", handlers.GinHandler(v1.AddAlert(ae)))
+		apiV1.PUT("/alerts/:id", handlers.GinHandler(v1.UpdateAlert(ae)))
+		apiV1.DELETE("/alerts/:id", handlers.GinHandler(v1.RemoveAlert(ae)))
+
+		apiV1.GET("/webhooks", handlers.GinHandler(v1.ListWebhooks(ds)))
+		apiV1.GET("/webhooks/:id", handlers.GinHandler(v1.GetWebhook(ds)))
+		apiV1.POST("/webhooks", handlers.GinHandler(v1.AddWebhook(ds)))
+		apiV1.PUT("/webhooks/:id", handlers.GinHandler(v1.UpdateWebhook(ds)))
+		apiV1.DELETE("/webhooks/:id", handlers.GinHandler(v1.RemoveWebhook(ds)))
+
+		apiV1.POST("/ws/subscribe", handlers.GinHandler(v1.Subscribe(pr)))
+		apiV1.GET("/ws/subscribe", handlers.GinHandler(v1.Subscribe(pr)))
+		apiV1.POST("/ws/unsubscribe", handlers.GinHandler(v1.Unsubscribe(pr)))
+		apiV1.GET("/ws/unsubscribe", handlers.GinHandler(v1.Unsubscribe(pr)))
+	}
+	// admin api is served only with the admin token
+	if token := config.GetEnv("CCDC_ADMINTOKEN"); token != "" {
+		admin := apiV1.Group("/admin", AdminOnly(token))
+		admin.GET("/retention", handlers.GinHandler(v1.RetentionStatus(rj)))
+		admin.POST("/retention", handlers.GinHandler(v1.TriggerRetention(rj)))
 	}
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		if err = v.RegisterValidation("symbols", validators.Symbols(sr)); err != nil {
 			return err
 		}
+		if err = v.RegisterValidation("providers", validators.Providers(pr)); err != nil {
+			return err
+		}
+		if err = v.RegisterValidation("pair", validators.Pair(sr)); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -0,0 +1,164 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/streamdp/ccd/alerts"
+	"github.com/streamdp/ccd/domain"
+	"github.com/streamdp/ccd/router/handlers"
+)
+
+const defaultDeliveriesLimit = 100
+
+// AlertQuery structure for easily json serialization/validation/binding POST and PUT query data
+type AlertQuery struct {
+	From      string  `json:"fsym" form:"fsym" binding:"required,symbols"`
+	To        string  `json:"tsym" form:"tsym" binding:"required,symbols"`
+	Condition string  `json:"condition" form:"condition" binding:"required,oneof=crosses above below change"`
+	Value     float64 `json:"value" form:"value" binding:"required,gt=0"`
+	Window    int64   `json:"window" form:"window" binding:"required_if=Condition change,min=0"`
+	Url       string  `json:"url" form:"url" binding:"required,url"`
+	Secret    string  `json:"secret" form:"secret"`
+	Enabled   *bool   `json:"enabled" form:"enabled"`
+}
+
+// AlertUri structure for binding the alert id from the url
+type AlertUri struct {
+	Id int64 `uri:"id" binding:"required,min=1"`
+}
+
+// DeliveriesQuery structure for binding GET query data of the delivery log
+type DeliveriesQuery struct {
+	Limit int `form:"limit" binding:"min=0,max=1000"`
+}
+
+func (q *AlertQuery) alert(id int64) *domain.Alert {
+	a := &domain.Alert{
+		Id:        id,
+		From:      strings.ToUpper(q.From),
+		To:        strings.ToUpper(q.To),
+		Condition: q.Condition,
+		Value:     q.Value,
+		Window:    q.Window,
+		Url:       q.Url,
+		Secret:    q.Secret,
+		Enabled:   true,
+	}
+	if q.Enabled != nil {
+		a.Enabled = *q.Enabled
+	}
+	return a
+}
+
+// ListAlerts return all alert rules
+func ListAlerts(e *alerts.Engine) handlers.HandlerFuncResError {
+	return func(c *gin.Context) (r handlers.Result, err error) {
+		list := e.List()
+		r.UpdateAllFields(http.StatusOK, fmt.Sprintf("Found %d alerts", len(list)), list)
+		return
+	}
+}
+
+// GetAlert return the alert rule with the selected id
+func GetAlert(e *alerts.Engine) handlers.HandlerFuncResError {
+	return func(c *gin.Context) (r handlers.Result, err error) {
+		u := AlertUri{}
+		if err = c.BindUri(&u); err != nil {
+			return
+		}
+		a, err := e.Get(u.Id)
+		if errors.Is(err, alerts.ErrNotFound) {
+			r.UpdateAllFields(http.StatusNotFound, err.Error(), nil)
+			return r, nil
+		}
+		r.UpdateAllFields(http.StatusOK, "Alert found", a)
+		return
+	}
+}
+
+// AddAlert rule, matches will be delivered to the url
+func AddAlert(e *alerts.Engine) handlers.HandlerFuncResError {
+	return func(c *gin.Context) (r handlers.Result, err error) {
+		q := AlertQuery{}
+		if err = c.Bind(&q); err != nil {
+			return
+		}
+		a := q.alert(0)
+		if err = e.Add(a); err != nil {
+			return
+		}
+		r.UpdateAllFields(http.StatusCreated, "Alert added successfully", a)
+		return
+	}
+}
+
+// UpdateAlert rule with the selected id
+func UpdateAlert(e *alerts.Engine) handlers.HandlerFuncResError {
+	return func(c *gin.Context) (r handlers.Result, err error) {
+		u := AlertUri{}
+		if err = c.BindUri(&u); err != nil {
+			return
+		}
+		q := AlertQuery{}
+		if err = c.Bind(&q); err != nil {
+			return
+		}
+		a := q.alert(u.Id)
+		if err = e.Update(a); errors.Is(err, alerts.ErrNotFound) {
+			r.UpdateAllFields(http.StatusNotFound, err.Error(), nil)
+			return r, nil
+		} else if err != nil {
+			return
+		}
+		r.UpdateAllFields(http.StatusOK, "Alert updated successfully", a)
+		return
+	}
+}
+
+// RemoveAlert rule with the selected id
+func RemoveAlert(e *alerts.Engine) handlers.HandlerFuncResError {
+	return func(c *gin.Context) (r handlers.Result, err error) {
+		u := AlertUri{}
+		if err = c.BindUri(&u); err != nil {
+			return
+		}
+		if err = e.Remove(u.Id); errors.Is(err, alerts.ErrNotFound) {
+			r.UpdateAllFields(http.StatusNotFound, err.Error(), nil)
+			return r, nil
+		} else if err != nil {
+			return
+		}
+		r.UpdateAllFields(http.StatusOK, "Alert removed successfully", nil)
+		return
+	}
+}
+
+// AlertDeliveries return the delivery log of the alert with the selected id, newest first
+func AlertDeliveries(e *alerts.Engine) handlers.HandlerFuncResError {
+	return func(c *gin.Context) (r handlers.Result, err error) {
+		u := AlertUri{}
+		if err = c.BindUri(&u); err != nil {
+			return
+		}
+		q := DeliveriesQuery{}
+		if err = c.BindQuery(&q); err != nil {
+			return
+		}
+		if q.Limit == 0 {
+			q.Limit = defaultDeliveriesLimit
+		}
+		deliveries, err := e.Deliveries(u.Id, q.Limit)
+		if errors.Is(err, alerts.ErrNotFound) {
+			r.UpdateAllFields(http.StatusNotFound, err.Error(), nil)
+			return r, nil
+		} else if err != nil {
+			return
+		}
+		r.UpdateAllFields(http.StatusOK, fmt.Sprintf("Found %d deliveries", len(deliveries)), deliveries)
+		return
+	}
+}
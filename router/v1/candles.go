@@ -0,0 +1,44 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/streamdp/ccd/db"
+	"github.com/streamdp/ccd/domain"
+	"github.com/streamdp/ccd/router/handlers"
+)
+
+const defaultCandlesLimit = 100
+
+// CandlesQuery structure for easily json serialization/validation/binding GET and POST query data
+type CandlesQuery struct {
+	From     string `json:"fsym" form:"fsym" binding:"required,symbols"`
+	To       string `json:"tsym" form:"tsym" binding:"required,symbols"`
+	Interval string `json:"interval" form:"interval" binding:"required,oneof=1m 5m 15m 1h 1d"`
+	Start    int64  `json:"start" form:"start" binding:"min=0"`
+	End      int64  `json:"end" form:"end" binding:"min=0"`
+	Limit    int    `json:"limit" form:"limit" binding:"min=0,max=1000"`
+}
+
+// Candles return OHLCV candles built from the stored data for the selected currencies pair
+func Candles(db db.Database) handlers.HandlerFuncResError {
+	return func(c *gin.Context) (r handlers.Result, err error) {
+		q := CandlesQuery{}
+		if err = c.Bind(&q); err != nil {
+			return
+		}
+		if q.Limit == 0 {
+			q.Limit = defaultCandlesLimit
+		}
+		var candles []*domain.Candle
+		from, to := strings.ToUpper(q.From), strings.ToUpper(q.To)
+		if candles, err = db.Candles(from, to, domain.CandleIntervals[q.Interval], q.Start, q.End, q.Limit); err != nil {
+			return
+		}
+		r.UpdateAllFields(http.StatusOK, fmt.Sprintf("Found %d candles", len(candles)), candles)
+		return
+	}
+}
@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -13,6 +14,7 @@ import (
 type CollectQuery struct {
 	From     string `json:"fsym" form:"fsym" binding:"required,symbols"`
 	To       string `json:"tsym" form:"tsym" binding:"required,symbols"`
+	Provider string `json:"provider" form:"provider" binding:"omitempty,providers"`
 	Interval int64  `json:"interval" form:"interval,default=60"`
 }
 
@@ -24,11 +26,11 @@ func AddWorker(p clients.RestApiPuller) handlers.HandlerFuncResError {
 		if err = c.Bind(&q); err != nil {
 			return
 		}
-		if t = p.Task(q.From, q.To); t != nil {
+		if t = p.Task(q.From, q.To, q.Provider); t != nil {
 			r.UpdateAllFields(http.StatusOK, "Data for this pair is already being collected", t)
 			return
 		}
-		t = p.AddTask(q.From, q.To, q.Interval)
+		t = p.AddTask(q.From, q.To, q.Provider, q.Interval)
 		r.UpdateAllFields(http.StatusCreated, "Data collection started", t)
 		return
 	}
@@ -41,18 +43,18 @@ func RemoveWorker(p clients.RestApiPuller) handlers.HandlerFuncResError {
 		if err = c.Bind(&q); err != nil {
 			return
 		}
-		if p.Task(q.From, q.To) == nil {
+		if p.Task(q.From, q.To, q.Provider) == nil {
 			r.UpdateAllFields(http.StatusOK, "No data is collected for this pair", nil)
 			return
 		}
-		p.RemoveTask(q.From, q.To)
+		p.RemoveTask(q.From, q.To, q.Provider)
 		r.UpdateAllFields(http.StatusOK, "Task stopped successfully", nil)
 		return
 	}
 }
 
 // PullingStatus return information about running pull tasks
-func PullingStatus(p clients.RestApiPuller, w clients.WsClient) handlers.HandlerFuncResError {
+func PullingStatus(p clients.RestApiPuller, pr *clients.Providers) handlers.HandlerFuncResError {
 	return func(c *gin.Context) (r handlers.Result, err error) {
 		r.UpdateAllFields(http.StatusOK, "Information about running tasks", nil)
 		var (
@@ -62,8 +64,8 @@ func PullingStatus(p clients.RestApiPuller, w clients.WsClient) handlers.Handler
 		if p != nil {
 			tasks = p.ListTasks()
 		}
-		if w != nil {
-			subscribes = w.ListSubscribes()
+		if pr != nil {
+			subscribes = pr.ListSubscribes()
 		}
 		if len(tasks) == 0 && len(subscribes) == 0 {
 			return
@@ -98,7 +100,7 @@ func UpdateWorker(p clients.RestApiPuller) handlers.HandlerFuncResError {
 		if err = c.Bind(&q); err != nil {
 			return
 		}
-		if t = p.Task(q.From, q.To); t == nil {
+		if t = p.Task(q.From, q.To, q.Provider); t == nil {
 			r.UpdateAllFields(http.StatusOK, "No data is collected for this pair", t)
 			return
 		}
@@ -108,12 +110,17 @@ func UpdateWorker(p clients.RestApiPuller) handlers.HandlerFuncResError {
 	}
 }
 
-func Subscribe(w clients.WsClient) handlers.HandlerFuncResError {
+// Subscribe to the ws channel of the selected (or primary) data provider to collect data for the currencies pair
+func Subscribe(pr *clients.Providers) handlers.HandlerFuncResError {
 	return func(c *gin.Context) (r handlers.Result, err error) {
 		q := CollectQuery{}
 		if err = c.Bind(&q); err != nil {
 			return
 		}
+		w := pr.Ws(q.Provider)
+		if w == nil {
+			return r, errors.New("selected data provider doesn't support websocket")
+		}
 		if err = w.Subscribe(q.From, q.To); err != nil {
 			r.UpdateAllFields(http.StatusOK, "subscribe error:", err)
 			return
@@ -123,12 +130,17 @@ func Subscribe(w clients.WsClient) handlers.HandlerFuncResError {
 	}
 }
 
-func Unsubscribe(w clients.WsClient) handlers.HandlerFuncResError {
+// Unsubscribe from the ws channel of the selected (or primary) data provider
+func Unsubscribe(pr *clients.Providers) handlers.HandlerFuncResError {
 	return func(c *gin.Context) (r handlers.Result, err error) {
 		q := CollectQuery{}
 		if err = c.Bind(&q); err != nil {
 			return
 		}
+		w := pr.Ws(q.Provider)
+		if w == nil {
+			return r, errors.New("selected data provider doesn't support websocket")
+		}
 		if err = w.Unsubscribe(q.From, q.To); err != nil {
 			r.UpdateAllFields(http.StatusOK, "Unsubscribe error:", err)
 			return
@@ -0,0 +1,50 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/streamdp/ccd/db"
+	"github.com/streamdp/ccd/domain"
+	"github.com/streamdp/ccd/router/handlers"
+)
+
+const defaultHistoryLimit = 100
+
+// HistoryQuery structure for easily json serialization/validation/binding GET and POST query data
+type HistoryQuery struct {
+	From   string `json:"fsym" form:"fsym" binding:"required,symbols"`
+	To     string `json:"tsym" form:"tsym" binding:"required,symbols"`
+	Start  int64  `json:"start" form:"start" binding:"min=0"`
+	End    int64  `json:"end" form:"end" binding:"min=0"`
+	Limit  int    `json:"limit" form:"limit" binding:"min=0,max=1000"`
+	Cursor int64  `json:"cursor" form:"cursor" binding:"min=0"`
+}
+
+// History return stored data for the selected currencies pair page by page, newest first
+func History(db db.Database) handlers.HandlerFuncResError {
+	return func(c *gin.Context) (r handlers.Result, err error) {
+		q := HistoryQuery{}
+		if err = c.Bind(&q); err != nil {
+			return
+		}
+		if q.Limit == 0 {
+			q.Limit = defaultHistoryLimit
+		}
+		from, to := strings.ToUpper(q.From), strings.ToUpper(q.To)
+		data, err := db.History(from, to, q.Start, q.End, q.Cursor, q.Limit)
+		if err != nil {
+			return
+		}
+		h := &domain.History{
+			Data: data,
+		}
+		if len(data) == q.Limit {
+			h.NextCursor = data[len(data)-1].Id
+		}
+		r.UpdateAllFields(http.StatusOK, fmt.Sprintf("Found %d rows", len(data)), h)
+		return
+	}
+}
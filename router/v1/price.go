@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/streamdp/ccd/clients"
@@ -12,34 +13,79 @@ import (
 	"github.com/streamdp/ccd/router/handlers"
 )
 
+// DbSource is the name of the price source when all data providers failed and the price was taken from the db
+const DbSource = "db"
+
 // PriceQuery structure for easily json serialization/validation/binding GET and POST query data
 type PriceQuery struct {
 	From string `json:"fsym" form:"fsym" binding:"required,symbols"`
 	To   string `json:"tsym" form:"tsym" binding:"required,symbols"`
+	Mode string `json:"mode" form:"mode" binding:"omitempty,oneof=last consensus"`
+}
+
+// PriceSource structure for easily json serialization of the price metadata
+type PriceSource struct {
+	Source string `json:"source"`
+	Age    int64  `json:"age"`
 }
 
-// LastPrice return up-to-date data for the selected currencies pair
-func LastPrice(r clients.RestClient, db db.Database, query *PriceQuery) (d *domain.Data, err error) {
+func newPriceSource(source string, d *domain.Data) *PriceSource {
+	return &PriceSource{
+		Source: source,
+		Age:    int64(time.Since(d.UpdatedAt()).Seconds()),
+	}
+}
+
+// LastPrice return up-to-date data for the selected currencies pair from the first available data provider or the
+// most recent stored data if all of them failed, with the name of the source and the age of the data in seconds
+func LastPrice(pr *clients.Providers, db db.Database, query *PriceQuery) (d *domain.Data, s *PriceSource, err error) {
+	var source string
 	from, to := strings.ToUpper(query.From), strings.ToUpper(query.To)
-	if d, err = r.Get(from, to); err != nil {
-		return db.GetLast(from, to)
+	if d, source, err = pr.GetFirst(from, to); err != nil {
+		if d, err = db.GetLast(from, to); err != nil {
+			return nil, nil, err
+		}
+		return d, newPriceSource(DbSource, d), nil
 	}
 	db.DataPipe() <- d
-	return
+	return d, newPriceSource(source, d), nil
 }
 
-// Price return up-to-date or most recent data for the selected currencies pair
-func Price(rc clients.RestClient, db db.Database) handlers.HandlerFuncResError {
+// ConsensusPrice return volume weighted price of all data providers without outliers for the selected currencies pair
+func ConsensusPrice(pr *clients.Providers, db db.Database, query *PriceQuery) (c *domain.Consensus, err error) {
+	from, to := strings.ToUpper(query.From), strings.ToUpper(query.To)
+	data := pr.GetAll(from, to)
+	if c, err = clients.NewConsensus(from, to, data); err != nil {
+		return nil, err
+	}
+	for _, d := range data {
+		db.DataPipe() <- d
+	}
+	return c, nil
+}
+
+// Price return up-to-date or most recent data for the selected currencies pair, or the consensus price of all
+// data providers when the consensus mode selected
+func Price(pr *clients.Providers, db db.Database) handlers.HandlerFuncResError {
 	return func(c *gin.Context) (r handlers.Result, err error) {
 		q := PriceQuery{}
 		if err = c.Bind(&q); err != nil {
 			return
 		}
-		p, err := LastPrice(rc, db, &q)
+		if q.Mode == "consensus" {
+			var cp *domain.Consensus
+			if cp, err = ConsensusPrice(pr, db, &q); err != nil {
+				return
+			}
+			r.UpdateAllFields(http.StatusOK, fmt.Sprintf("Consensus price of %d sources", len(cp.Sources)), cp)
+			return
+		}
+		p, s, err := LastPrice(pr, db, &q)
 		if err != nil {
 			return
 		}
 		r.UpdateAllFields(http.StatusOK, fmt.Sprintf("Most recent price, updated at %d", p.LastUpdate), p)
+		r.UpdateMetaField(s)
 		return
 	}
 }
@@ -0,0 +1,34 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/streamdp/ccd/retention"
+	"github.com/streamdp/ccd/router/handlers"
+)
+
+// RetentionStatus return the retention policies and the result of the last run of the job
+func RetentionStatus(rj *retention.Job) handlers.HandlerFuncResError {
+	return func(c *gin.Context) (r handlers.Result, err error) {
+		s := rj.Status()
+		r.UpdateAllFields(http.StatusOK, fmt.Sprintf("Found %d retention policies", len(s.Policies)), s)
+		return
+	}
+}
+
+// TriggerRetention start the retention job run in the background, its result is reported by the status
+func TriggerRetention(rj *retention.Job) handlers.HandlerFuncResError {
+	return func(c *gin.Context) (r handlers.Result, err error) {
+		if err = rj.Start(); errors.Is(err, retention.ErrRunning) {
+			r.UpdateAllFields(http.StatusConflict, err.Error(), nil)
+			return r, nil
+		} else if err != nil {
+			return
+		}
+		r.UpdateAllFields(http.StatusAccepted, "Retention job started", nil)
+		return
+	}
+}
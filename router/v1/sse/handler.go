@@ -0,0 +1,241 @@
+package sse
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/streamdp/ccd/db"
+	"github.com/streamdp/ccd/domain"
+	"github.com/streamdp/ccd/hub"
+	"github.com/streamdp/ccd/repos"
+	"github.com/streamdp/ccd/router/handlers"
+)
+
+const (
+	keepAlive     = 15 * time.Second
+	hubBufferSize = 256
+	maxPairs      = 32
+	resumePage    = 1000
+	eventName     = "data"
+)
+
+type pair struct {
+	from string
+	to   string
+}
+
+type sseHandler struct {
+	c     *gin.Context
+	l     *log.Logger
+	db    db.Database
+	hb    *hub.Hub
+	pairs []pair
+	names map[string]struct{}
+	// id of the last event sent, to skip the live events that were already sent on resume
+	last int64
+}
+
+// HandleStream - streams the live data of the selected currencies pairs as server-sent events. The event id is the
+// monotonic id of the hub event, so a reconnecting peer with the Last-Event-ID header first receives the events it
+// missed. If some of them are not kept by the hub anymore, the data stored after the time of the last event is sent
+// first without ids, so a few events could be received twice after a restart or a long disconnect.
+func HandleStream(l *log.Logger, db db.Database, sr *repos.SymbolRepo, hb *hub.Hub) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		pairs, err := parsePairs(c.Query("pairs"), sr)
+		if err != nil {
+			returnAnErrorToTheClient(c, err)
+			return
+		}
+		lastEventId, err := parseLastEventId(c)
+		if err != nil {
+			returnAnErrorToTheClient(c, err)
+			return
+		}
+		s := &sseHandler{
+			c:     c,
+			l:     l,
+			db:    db,
+			hb:    hb,
+			pairs: pairs,
+			names: map[string]struct{}{},
+		}
+		// subscribe before the resume, so nothing is lost between the resumed and the live events
+		sub := hb.Subscribe(hubBufferSize)
+		defer hb.Unsubscribe(sub)
+		for _, p := range pairs {
+			sub.Add(p.from, p.to)
+			s.names[domain.PairName(p.from, p.to)] = struct{}{}
+		}
+
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+		c.Header("X-Accel-Buffering", "no")
+		c.Status(http.StatusOK)
+
+		if lastEventId > 0 {
+			s.last = lastEventId
+			if err = s.resume(lastEventId); err != nil {
+				l.Println(err)
+				return
+			}
+		}
+		s.c.Writer.Flush()
+		s.serve(sub)
+	}
+}
+
+func (s *sseHandler) serve(sub *hub.Subscriber) {
+	ticker := time.NewTicker(keepAlive)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.c.Request.Context().Done():
+			return
+		case <-ticker.C:
+			if _, err := fmt.Fprint(s.c.Writer, ": keepalive\n\n"); err != nil {
+				return
+			}
+		case e, ok := <-sub.C():
+			if !ok {
+				return
+			}
+			if err := s.send(e); err != nil {
+				s.l.Println(err)
+				return
+			}
+		}
+		s.c.Writer.Flush()
+	}
+}
+
+// resume send the events published after the last event id, they are read from the hub history page by page. The
+// stored data of the events that are not kept by the hub anymore is sent before them.
+func (s *sseHandler) resume(lastEventId int64) error {
+	events, complete := s.hb.Since(lastEventId, resumePage)
+	if !complete {
+		if err := s.resumeStored(lastEventId); err != nil {
+			return err
+		}
+	}
+	for len(events) > 0 {
+		for _, e := range events {
+			if _, ok := s.names[domain.PairName(e.Data.FromSymbol, e.Data.ToSymbol)]; !ok {
+				continue
+			}
+			if err := s.send(e); err != nil {
+				return err
+			}
+		}
+		if len(events) < resumePage {
+			return nil
+		}
+		events, _ = s.hb.Since(events[len(events)-1].Id, resumePage)
+	}
+	return nil
+}
+
+// resumeStored send the data of every pair stored after the time of the last event, the rows are read and sent
+// page by page in the insert order without ids
+func (s *sseHandler) resumeStored(lastEventId int64) error {
+	since := lastEventId / 1000 // the event ids start from the time in microseconds, stored is in milliseconds
+	for _, p := range s.pairs {
+		var cursor int64
+		for {
+			data, err := s.db.Since(p.from, p.to, since, cursor, resumePage)
+			if err != nil {
+				return err
+			}
+			for _, d := range data {
+				if err = s.write(0, d); err != nil {
+					return err
+				}
+			}
+			if len(data) < resumePage {
+				break
+			}
+			cursor = data[len(data)-1].Id
+		}
+	}
+	return nil
+}
+
+// send the event unless it was already sent
+func (s *sseHandler) send(e hub.Event) error {
+	if e.Id <= s.last {
+		return nil
+	}
+	if err := s.write(e.Id, e.Data); err != nil {
+		return err
+	}
+	s.last = e.Id
+	return nil
+}
+
+// write the data as the server-sent event, the id line is omitted if the id is 0, so the peer keeps the last one
+func (s *sseHandler) write(id int64, d *domain.Data) error {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	if id > 0 {
+		if _, err = fmt.Fprintf(s.c.Writer, "id: %d\n", id); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(s.c.Writer, "event: %s\ndata: %s\n\n", eventName, data)
+	return err
+}
+
+func parsePairs(query string, sr *repos.SymbolRepo) (pairs []pair, err error) {
+	if query == "" {
+		return nil, errors.New("invalid request: pairs should be specified like pairs=BTC:USD,ETH:EUR")
+	}
+	seen := map[string]struct{}{}
+	for _, name := range strings.Split(query, ",") {
+		parts := strings.Split(strings.TrimSpace(name), ":")
+		if len(parts) != 2 || !sr.IsPresent(parts[0]) || !sr.IsPresent(parts[1]) {
+			return nil, fmt.Errorf("invalid request: wrong currencies pair %q", name)
+		}
+		p := pair{from: strings.ToUpper(parts[0]), to: strings.ToUpper(parts[1])}
+		if _, ok := seen[p.from+":"+p.to]; ok {
+			continue
+		}
+		seen[p.from+":"+p.to] = struct{}{}
+		pairs = append(pairs, p)
+	}
+	if len(pairs) > maxPairs {
+		return nil, fmt.Errorf("invalid request: too many pairs, maximum is %d", maxPairs)
+	}
+	return pairs, nil
+}
+
+// parseLastEventId from the Last-Event-ID header, or from the lastEventId query parameter for the peers that can't
+// set headers
+func parseLastEventId(c *gin.Context) (int64, error) {
+	id := c.GetHeader("Last-Event-ID")
+	if id == "" {
+		id = c.Query("lastEventId")
+	}
+	if id == "" {
+		return 0, nil
+	}
+	lastEventId, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || lastEventId < 0 {
+		return 0, fmt.Errorf("invalid request: wrong last event id %q", id)
+	}
+	return lastEventId, nil
+}
+
+func returnAnErrorToTheClient(c *gin.Context, err error) {
+	r := handlers.Result{}
+	r.UpdateAllFields(http.StatusBadRequest, err.Error(), nil)
+	c.AbortWithStatusJSON(http.StatusBadRequest, r)
+}
@@ -0,0 +1,91 @@
+package sse
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/streamdp/ccd/db/memory"
+	"github.com/streamdp/ccd/domain"
+	"github.com/streamdp/ccd/hub"
+	"github.com/streamdp/ccd/repos"
+)
+
+// stream request the stream for a while and return the received body
+func stream(t *testing.T, d *memory.Db, hb *hub.Hub, lastEventId int64) string {
+	sr := repos.NewSymbolRepository(d)
+	if err := sr.Load(); err != nil {
+		t.Fatal(err)
+	}
+	gin.SetMode(gin.TestMode)
+	e := gin.New()
+	e.GET("/stream", HandleStream(log.New(io.Discard, "", 0), d, sr, hb))
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	req := httptest.NewRequest(http.MethodGet, "/stream?pairs=BTC:USD", nil).WithContext(ctx)
+	req.Header.Set("Last-Event-ID", fmt.Sprint(lastEventId))
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, req)
+	return w.Body.String()
+}
+
+func tick(price float64, lastUpdate int64) *domain.Data {
+	return &domain.Data{FromSymbol: "BTC", ToSymbol: "USD", Price: price, LastUpdate: lastUpdate, Provider: "test"}
+}
+
+func TestResumeFromHub(t *testing.T) {
+	d, hb := memory.New(), hub.New()
+	sub := hb.SubscribeAll(10)
+	for i := 1; i <= 3; i++ {
+		hb.Publish(tick(float64(i), time.Now().UnixMilli()))
+		hb.Publish(&domain.Data{FromSymbol: "ETH", ToSymbol: "USD", Price: 100})
+	}
+	first := (<-sub.C()).Id
+
+	body := stream(t, d, hb, first)
+	if strings.Contains(body, `"price":1,`) || strings.Contains(body, `"price":100,`) ||
+		!strings.Contains(body, `"price":2,`) || !strings.Contains(body, `"price":3,`) {
+		t.Errorf("got unexpected events %s", body)
+	}
+	if strings.Count(body, "id: ") != 2 {
+		t.Errorf("got %d ids, want 2", strings.Count(body, "id: "))
+	}
+}
+
+func TestResumeFromStored(t *testing.T) {
+	d := memory.New()
+	now := time.Now()
+	if _, err := d.Insert(tick(1, now.Add(-2*time.Minute).UnixMilli())); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(2 * time.Millisecond)
+	// the last event id of the previous run
+	lastEventId := time.Now().UnixMicro()
+	time.Sleep(2 * time.Millisecond)
+	// the rows are resumed by the time they are stored, not by the exchange time
+	for i, ts := range []time.Time{now.Add(-30 * time.Second), now.Add(-time.Hour)} {
+		if _, err := d.Insert(tick(float64(i+2), ts.UnixMilli())); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hb := hub.New()
+	hb.Publish(tick(4, now.UnixMilli()))
+
+	body := stream(t, d, hb, lastEventId)
+	i2, i3 := strings.Index(body, `"price":2,`), strings.Index(body, `"price":3,`)
+	i4 := strings.Index(body, `"price":4,`)
+	if strings.Contains(body, `"price":1,`) || i2 < 0 || i3 < i2 || i4 < i3 {
+		t.Errorf("got unexpected events %s", body)
+	}
+	// the stored rows have no ids, the peer keeps the last event id until the hub events
+	if strings.Count(body, "id: ") != 1 {
+		t.Errorf("got %d ids, want 1", strings.Count(body, "id: "))
+	}
+}
@@ -0,0 +1,16 @@
+package validators
+
+import (
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/streamdp/ccd/repos"
+)
+
+// Pair - validate the field so that the value is the currencies pair like "BTC:USD" from the list of currencies
+func Pair(sr *repos.SymbolRepo) func(fl validator.FieldLevel) bool {
+	return func(fl validator.FieldLevel) bool {
+		parts := strings.Split(fl.Field().String(), ":")
+		return len(parts) == 2 && sr.IsPresent(parts[0]) && sr.IsPresent(parts[1])
+	}
+}
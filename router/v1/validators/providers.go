@@ -0,0 +1,13 @@
+package validators
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/streamdp/ccd/clients"
+)
+
+// Providers - validate the field so that the value is from the list of running data providers
+func Providers(pr *clients.Providers) func(fl validator.FieldLevel) bool {
+	return func(fl validator.FieldLevel) bool {
+		return pr.IsPresent(fl.Field().String())
+	}
+}
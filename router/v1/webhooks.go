@@ -0,0 +1,127 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/streamdp/ccd/domain"
+	"github.com/streamdp/ccd/router/handlers"
+	"github.com/streamdp/ccd/webhook"
+)
+
+// WebhookQuery structure for easily json serialization/validation/binding POST and PUT query data
+type WebhookQuery struct {
+	Pairs   []string `json:"pairs" form:"pairs" binding:"dive,pair"`
+	Url     string   `json:"url" form:"url" binding:"required,url"`
+	Secret  string   `json:"secret" form:"secret"`
+	Window  int64    `json:"window" form:"window,default=1" binding:"min=0,max=3600"`
+	Enabled *bool    `json:"enabled" form:"enabled"`
+}
+
+// WebhookUri structure for binding the webhook id from the url
+type WebhookUri struct {
+	Id int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (q *WebhookQuery) webhook(id int64) *domain.Webhook {
+	w := &domain.Webhook{
+		Id:      id,
+		Url:     q.Url,
+		Secret:  q.Secret,
+		Window:  q.Window,
+		Enabled: true,
+	}
+	for _, p := range q.Pairs {
+		w.Pairs = append(w.Pairs, strings.ToUpper(p))
+	}
+	if q.Enabled != nil {
+		w.Enabled = *q.Enabled
+	}
+	return w
+}
+
+// ListWebhooks return all webhooks
+func ListWebhooks(ds *webhook.Dispatcher) handlers.HandlerFuncResError {
+	return func(c *gin.Context) (r handlers.Result, err error) {
+		list := ds.List()
+		r.UpdateAllFields(http.StatusOK, fmt.Sprintf("Found %d webhooks", len(list)), list)
+		return
+	}
+}
+
+// GetWebhook return the webhook with the selected id
+func GetWebhook(ds *webhook.Dispatcher) handlers.HandlerFuncResError {
+	return func(c *gin.Context) (r handlers.Result, err error) {
+		u := WebhookUri{}
+		if err = c.BindUri(&u); err != nil {
+			return
+		}
+		w, err := ds.Get(u.Id)
+		if errors.Is(err, webhook.ErrNotFound) {
+			r.UpdateAllFields(http.StatusNotFound, err.Error(), nil)
+			return r, nil
+		}
+		r.UpdateAllFields(http.StatusOK, "Webhook found", w)
+		return
+	}
+}
+
+// AddWebhook that will receive the ticks of the selected pairs
+func AddWebhook(ds *webhook.Dispatcher) handlers.HandlerFuncResError {
+	return func(c *gin.Context) (r handlers.Result, err error) {
+		q := WebhookQuery{}
+		if err = c.Bind(&q); err != nil {
+			return
+		}
+		w := q.webhook(0)
+		if err = ds.Add(w); err != nil {
+			return
+		}
+		r.UpdateAllFields(http.StatusCreated, "Webhook added successfully", w)
+		return
+	}
+}
+
+// UpdateWebhook with the selected id, it also enables the webhook that was disabled after repeated failures
+func UpdateWebhook(ds *webhook.Dispatcher) handlers.HandlerFuncResError {
+	return func(c *gin.Context) (r handlers.Result, err error) {
+		u := WebhookUri{}
+		if err = c.BindUri(&u); err != nil {
+			return
+		}
+		q := WebhookQuery{}
+		if err = c.Bind(&q); err != nil {
+			return
+		}
+		w := q.webhook(u.Id)
+		if err = ds.Update(w); errors.Is(err, webhook.ErrNotFound) {
+			r.UpdateAllFields(http.StatusNotFound, err.Error(), nil)
+			return r, nil
+		} else if err != nil {
+			return
+		}
+		r.UpdateAllFields(http.StatusOK, "Webhook updated successfully", w)
+		return
+	}
+}
+
+// RemoveWebhook with the selected id
+func RemoveWebhook(ds *webhook.Dispatcher) handlers.HandlerFuncResError {
+	return func(c *gin.Context) (r handlers.Result, err error) {
+		u := WebhookUri{}
+		if err = c.BindUri(&u); err != nil {
+			return
+		}
+		if err = ds.Remove(u.Id); errors.Is(err, webhook.ErrNotFound) {
+			r.UpdateAllFields(http.StatusNotFound, err.Error(), nil)
+			return r, nil
+		} else if err != nil {
+			return
+		}
+		r.UpdateAllFields(http.StatusOK, "Webhook removed successfully", nil)
+		return
+	}
+}
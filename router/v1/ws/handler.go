@@ -6,11 +6,14 @@ import (
 	"errors"
 	"log"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/streamdp/ccd/db"
 	"github.com/streamdp/ccd/domain"
+	"github.com/streamdp/ccd/hub"
+	"github.com/streamdp/ccd/repos"
 	"github.com/streamdp/ccd/router/handlers"
 	"nhooyr.io/websocket"
 
@@ -21,8 +24,21 @@ import (
 const (
 	writeWait      = 10 * time.Second
 	maxMessageSize = 512
+	hubBufferSize  = 256
 )
 
+const (
+	actionPrice       = "price"
+	actionSubscribe   = "subscribe"
+	actionUnsubscribe = "unsubscribe"
+)
+
+// request from the peer, an empty action means a single last price request
+type request struct {
+	Action string `json:"action"`
+	v1.PriceQuery
+}
+
 type wsHandler struct {
 	ctx         context.Context
 	l           *log.Logger
@@ -30,12 +46,18 @@ type wsHandler struct {
 	conn        *websocket.Conn
 	messagePipe chan []byte
 
-	rc clients.RestClient
+	pr *clients.Providers
+	sr *repos.SymbolRepo
 	db db.Database
+
+	h   *hub.Hub
+	sub *hub.Subscriber
+	wg  sync.WaitGroup
 }
 
-// HandleWs - handles websocket requests from the peer.
-func HandleWs(r clients.RestClient, l *log.Logger, db db.Database) gin.HandlerFunc {
+// HandleWs - handles websocket requests from the peer. The peer can request the last price or subscribe to the live
+// data of the currencies pairs, which is fanned out to it from the hub.
+func HandleWs(pr *clients.Providers, sr *repos.SymbolRepo, l *log.Logger, db db.Database, hb *hub.Hub) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		ctx, cancel := context.WithCancel(context.Background())
 		conn, err := websocket.Accept(c.Writer, c.Request, &websocket.AcceptOptions{
@@ -52,8 +74,10 @@ func HandleWs(r clients.RestClient, l *log.Logger, db db.Database) gin.HandlerFu
 			cancel:      cancel,
 			conn:        conn,
 			messagePipe: make(chan []byte, 256),
-			rc:          r,
+			pr:          pr,
+			sr:          sr,
 			db:          db,
+			h:           hb,
 		}
 		h.conn.SetReadLimit(maxMessageSize)
 		go h.handleMessagePipe()
@@ -64,6 +88,10 @@ func HandleWs(r clients.RestClient, l *log.Logger, db db.Database) gin.HandlerFu
 func (w *wsHandler) handleClientRequests() {
 	defer func() {
 		w.cancel()
+		if w.sub != nil {
+			w.h.Unsubscribe(w.sub)
+		}
+		w.wg.Wait()
 		close(w.messagePipe)
 	}()
 	for {
@@ -72,9 +100,9 @@ func (w *wsHandler) handleClientRequests() {
 			return
 		default:
 			var (
-				data  []byte
-				err   error
-				query = v1.PriceQuery{}
+				data []byte
+				err  error
+				req  = request{}
 			)
 			if _, data, err = w.conn.Read(w.ctx); err != nil {
 				w.l.Println(err)
@@ -83,24 +111,97 @@ func (w *wsHandler) handleClientRequests() {
 				}
 				continue
 			}
-			if err = json.Unmarshal(data, &query); err != nil {
+			if err = json.Unmarshal(data, &req); err != nil {
 				w.returnAnErrorToTheClient(errors.New(
-					"invalid request: the request should look like {\"fsym\":\"CRYPTO\",\"tsym\":\"COMMON\"}",
+					"invalid request: the request should look like " +
+						"{\"action\":\"subscribe\",\"fsym\":\"CRYPTO\",\"tsym\":\"COMMON\"}",
 				))
 				continue
 			}
-			if data, err = w.getLastPrice(&query); err != nil {
+			w.handleRequest(&req)
+		}
+	}
+}
+
+func (w *wsHandler) handleRequest(req *request) {
+	switch req.Action {
+	case "", actionPrice:
+		if !w.isPresent(req.From, req.To) {
+			w.returnAnErrorToTheClient(errors.New("invalid request: wrong currencies pair " +
+				domain.PairName(req.From, req.To)))
+			return
+		}
+		data, err := w.getLastPrice(&req.PriceQuery)
+		if err != nil {
+			w.l.Println(err)
+			return
+		}
+		w.send(data)
+	case actionSubscribe, actionUnsubscribe:
+		if req.From == "" || req.To == "" {
+			w.returnAnErrorToTheClient(errors.New("invalid request: fsym and tsym should be specified"))
+			return
+		}
+		pair := domain.PairName(req.From, req.To)
+		if req.Action == actionSubscribe {
+			if !w.isPresent(req.From, req.To) {
+				w.returnAnErrorToTheClient(errors.New("invalid request: wrong currencies pair " + pair))
+				return
+			}
+			w.subscriber().Add(req.From, req.To)
+			w.returnResultToTheClient("subscribed to " + pair)
+			return
+		}
+		if w.sub != nil {
+			w.sub.Remove(req.From, req.To)
+		}
+		w.returnResultToTheClient("unsubscribed from " + pair)
+	default:
+		w.returnAnErrorToTheClient(errors.New("invalid request: unknown action " + req.Action))
+	}
+}
+
+// isPresent return true if both currencies of the pair are from the list of currencies
+func (w *wsHandler) isPresent(from, to string) bool {
+	return w.sr.IsPresent(from) && w.sr.IsPresent(to)
+}
+
+// subscriber lazily subscribes the peer to the hub and starts forwarding the data to it
+func (w *wsHandler) subscriber() *hub.Subscriber {
+	if w.sub != nil {
+		return w.sub
+	}
+	w.sub = w.h.Subscribe(hubBufferSize)
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		for e := range w.sub.C() {
+			data, err := json.Marshal(e.Data)
+			if err != nil {
 				w.l.Println(err)
 				continue
 			}
-			w.messagePipe <- data
+			if !w.send(data) {
+				return
+			}
 		}
+	}()
+	return w.sub
+}
+
+// send the message to the peer unless the connection is already closing
+func (w *wsHandler) send(message []byte) bool {
+	select {
+	case w.messagePipe <- message:
+		return true
+	case <-w.ctx.Done():
+		return false
 	}
 }
 
 func (w *wsHandler) getLastPrice(q *v1.PriceQuery) (result []byte, err error) {
 	var data *domain.Data
-	if data, err = v1.LastPrice(w.rc, w.db, q); err != nil {
+	if data, _, err = v1.LastPrice(w.pr, w.db, q); err != nil {
 		return
 	}
 	if result, err = json.Marshal(&data); err != nil {
@@ -134,5 +235,16 @@ func (w *wsHandler) returnAnErrorToTheClient(err error) {
 		w.l.Println(err)
 		return
 	}
-	w.messagePipe <- binaryString
+	w.send(binaryString)
+}
+
+func (w *wsHandler) returnResultToTheClient(message string) {
+	r := handlers.Result{}
+	r.UpdateAllFields(http.StatusOK, message, nil)
+	binaryString, err := json.Marshal(&r)
+	if err != nil {
+		w.l.Println(err)
+		return
+	}
+	w.send(binaryString)
 }
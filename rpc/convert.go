@@ -0,0 +1,64 @@
+package rpc
+
+import (
+	"sync/atomic"
+
+	"github.com/streamdp/ccd/clients"
+	"github.com/streamdp/ccd/domain"
+	v1 "github.com/streamdp/ccd/router/v1"
+	"github.com/streamdp/ccd/rpc/pb"
+)
+
+func toData(d *domain.Data) *pb.Data {
+	if d == nil {
+		return nil
+	}
+	return &pb.Data{
+		Id:               d.Id,
+		FromSym:          d.FromSymbol,
+		ToSym:            d.ToSymbol,
+		Change_24Hour:    d.Change24Hour,
+		ChangePct_24Hour: d.ChangePct24Hour,
+		Open_24Hour:      d.Open24Hour,
+		Volume_24Hour:    d.Volume24Hour,
+		Low_24Hour:       d.Low24Hour,
+		High_24Hour:      d.High24Hour,
+		Price:            d.Price,
+		Supply:           d.Supply,
+		MktCap:           d.MktCap,
+		LastUpdate:       d.LastUpdate,
+		DisplayDataRaw:   d.DisplayDataRaw,
+		Provider:         d.Provider,
+	}
+}
+
+func toPriceSource(s *v1.PriceSource) *pb.PriceSource {
+	if s == nil {
+		return nil
+	}
+	return &pb.PriceSource{Source: s.Source, Age: s.Age}
+}
+
+func toConsensus(c *domain.Consensus) *pb.Consensus {
+	if c == nil {
+		return nil
+	}
+	r := &pb.Consensus{FromSym: c.FromSymbol, ToSym: c.ToSymbol, Price: c.Price}
+	for _, s := range c.Sources {
+		r.Sources = append(r.Sources, &pb.ConsensusSource{
+			Provider:   s.Provider,
+			Price:      s.Price,
+			Volume:     s.Volume,
+			LastUpdate: s.LastUpdate,
+			Outlier:    s.Outlier,
+		})
+	}
+	return r
+}
+
+func toTask(t *clients.Task) *pb.Task {
+	if t == nil {
+		return nil
+	}
+	return &pb.Task{From: t.From, To: t.To, Provider: t.Provider, Interval: atomic.LoadInt64(&t.Interval)}
+}
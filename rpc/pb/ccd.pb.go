@@ -0,0 +1,1198 @@
+// The gRPC api of the crypto currency data service, it runs alongside the rest api and shares the same providers,
+// puller and database. Regenerate the go code after changes with:
+//
+//   protoc --go_out=. --go_opt=paths=source_relative \
+//     --go-grpc_out=. --go-grpc_opt=paths=source_relative rpc/pb/ccd.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        (unknown)
+// source: rpc/pb/ccd.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// PriceRequest is the same as the price query of the rest api, the mode is "last" (default) or "consensus"
+type PriceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fsym string `protobuf:"bytes,1,opt,name=fsym,proto3" json:"fsym,omitempty"`
+	Tsym string `protobuf:"bytes,2,opt,name=tsym,proto3" json:"tsym,omitempty"`
+	Mode string `protobuf:"bytes,3,opt,name=mode,proto3" json:"mode,omitempty"`
+}
+
+func (x *PriceRequest) Reset() {
+	*x = PriceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_pb_ccd_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PriceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceRequest) ProtoMessage() {}
+
+func (x *PriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_pb_ccd_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceRequest.ProtoReflect.Descriptor instead.
+func (*PriceRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_pb_ccd_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PriceRequest) GetFsym() string {
+	if x != nil {
+		return x.Fsym
+	}
+	return ""
+}
+
+func (x *PriceRequest) GetTsym() string {
+	if x != nil {
+		return x.Tsym
+	}
+	return ""
+}
+
+func (x *PriceRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+// TaskRequest is the same as the collect query of the rest api, it is used by the task and subscribe methods
+type TaskRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fsym     string `protobuf:"bytes,1,opt,name=fsym,proto3" json:"fsym,omitempty"`
+	Tsym     string `protobuf:"bytes,2,opt,name=tsym,proto3" json:"tsym,omitempty"`
+	Provider string `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
+	Interval int64  `protobuf:"varint,4,opt,name=interval,proto3" json:"interval,omitempty"`
+}
+
+func (x *TaskRequest) Reset() {
+	*x = TaskRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_pb_ccd_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskRequest) ProtoMessage() {}
+
+func (x *TaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_pb_ccd_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskRequest.ProtoReflect.Descriptor instead.
+func (*TaskRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_pb_ccd_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TaskRequest) GetFsym() string {
+	if x != nil {
+		return x.Fsym
+	}
+	return ""
+}
+
+func (x *TaskRequest) GetTsym() string {
+	if x != nil {
+		return x.Tsym
+	}
+	return ""
+}
+
+func (x *TaskRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *TaskRequest) GetInterval() int64 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+// StreamRequest selects the currencies pairs to stream, like ["BTC:USD","ETH:EUR"]
+type StreamRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pairs []string `protobuf:"bytes,1,rep,name=pairs,proto3" json:"pairs,omitempty"`
+}
+
+func (x *StreamRequest) Reset() {
+	*x = StreamRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_pb_ccd_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamRequest) ProtoMessage() {}
+
+func (x *StreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_pb_ccd_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamRequest.ProtoReflect.Descriptor instead.
+func (*StreamRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_pb_ccd_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StreamRequest) GetPairs() []string {
+	if x != nil {
+		return x.Pairs
+	}
+	return nil
+}
+
+// Empty request or reply
+type Empty struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_pb_ccd_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_pb_ccd_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_rpc_pb_ccd_proto_rawDescGZIP(), []int{3}
+}
+
+// Data of the currencies pair, the last update is the unix time in seconds or milliseconds depending on the provider
+type Data struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id               int64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	FromSym          string  `protobuf:"bytes,2,opt,name=from_sym,json=fromSym,proto3" json:"from_sym,omitempty"`
+	ToSym            string  `protobuf:"bytes,3,opt,name=to_sym,json=toSym,proto3" json:"to_sym,omitempty"`
+	Change_24Hour    float64 `protobuf:"fixed64,4,opt,name=change_24_hour,json=change24Hour,proto3" json:"change_24_hour,omitempty"`
+	ChangePct_24Hour float64 `protobuf:"fixed64,5,opt,name=change_pct_24_hour,json=changePct24Hour,proto3" json:"change_pct_24_hour,omitempty"`
+	Open_24Hour      float64 `protobuf:"fixed64,6,opt,name=open_24_hour,json=open24Hour,proto3" json:"open_24_hour,omitempty"`
+	Volume_24Hour    float64 `protobuf:"fixed64,7,opt,name=volume_24_hour,json=volume24Hour,proto3" json:"volume_24_hour,omitempty"`
+	Low_24Hour       float64 `protobuf:"fixed64,8,opt,name=low_24_hour,json=low24Hour,proto3" json:"low_24_hour,omitempty"`
+	High_24Hour      float64 `protobuf:"fixed64,9,opt,name=high_24_hour,json=high24Hour,proto3" json:"high_24_hour,omitempty"`
+	Price            float64 `protobuf:"fixed64,10,opt,name=price,proto3" json:"price,omitempty"`
+	Supply           float64 `protobuf:"fixed64,11,opt,name=supply,proto3" json:"supply,omitempty"`
+	MktCap           float64 `protobuf:"fixed64,12,opt,name=mkt_cap,json=mktCap,proto3" json:"mkt_cap,omitempty"`
+	LastUpdate       int64   `protobuf:"varint,13,opt,name=last_update,json=lastUpdate,proto3" json:"last_update,omitempty"`
+	DisplayDataRaw   string  `protobuf:"bytes,14,opt,name=display_data_raw,json=displayDataRaw,proto3" json:"display_data_raw,omitempty"`
+	Provider         string  `protobuf:"bytes,15,opt,name=provider,proto3" json:"provider,omitempty"`
+}
+
+func (x *Data) Reset() {
+	*x = Data{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_pb_ccd_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Data) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data) ProtoMessage() {}
+
+func (x *Data) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_pb_ccd_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data.ProtoReflect.Descriptor instead.
+func (*Data) Descriptor() ([]byte, []int) {
+	return file_rpc_pb_ccd_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Data) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Data) GetFromSym() string {
+	if x != nil {
+		return x.FromSym
+	}
+	return ""
+}
+
+func (x *Data) GetToSym() string {
+	if x != nil {
+		return x.ToSym
+	}
+	return ""
+}
+
+func (x *Data) GetChange_24Hour() float64 {
+	if x != nil {
+		return x.Change_24Hour
+	}
+	return 0
+}
+
+func (x *Data) GetChangePct_24Hour() float64 {
+	if x != nil {
+		return x.ChangePct_24Hour
+	}
+	return 0
+}
+
+func (x *Data) GetOpen_24Hour() float64 {
+	if x != nil {
+		return x.Open_24Hour
+	}
+	return 0
+}
+
+func (x *Data) GetVolume_24Hour() float64 {
+	if x != nil {
+		return x.Volume_24Hour
+	}
+	return 0
+}
+
+func (x *Data) GetLow_24Hour() float64 {
+	if x != nil {
+		return x.Low_24Hour
+	}
+	return 0
+}
+
+func (x *Data) GetHigh_24Hour() float64 {
+	if x != nil {
+		return x.High_24Hour
+	}
+	return 0
+}
+
+func (x *Data) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Data) GetSupply() float64 {
+	if x != nil {
+		return x.Supply
+	}
+	return 0
+}
+
+func (x *Data) GetMktCap() float64 {
+	if x != nil {
+		return x.MktCap
+	}
+	return 0
+}
+
+func (x *Data) GetLastUpdate() int64 {
+	if x != nil {
+		return x.LastUpdate
+	}
+	return 0
+}
+
+func (x *Data) GetDisplayDataRaw() string {
+	if x != nil {
+		return x.DisplayDataRaw
+	}
+	return ""
+}
+
+func (x *Data) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+// PriceSource is the source of the price ("db" or the provider name) and its age in seconds
+type PriceSource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Source string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Age    int64  `protobuf:"varint,2,opt,name=age,proto3" json:"age,omitempty"`
+}
+
+func (x *PriceSource) Reset() {
+	*x = PriceSource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_pb_ccd_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PriceSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceSource) ProtoMessage() {}
+
+func (x *PriceSource) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_pb_ccd_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceSource.ProtoReflect.Descriptor instead.
+func (*PriceSource) Descriptor() ([]byte, []int) {
+	return file_rpc_pb_ccd_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PriceSource) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *PriceSource) GetAge() int64 {
+	if x != nil {
+		return x.Age
+	}
+	return 0
+}
+
+// ConsensusSource price and 24h volume reported by the data provider, outliers are not included in the consensus
+type ConsensusSource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Provider   string  `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	Price      float64 `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"`
+	Volume     float64 `protobuf:"fixed64,3,opt,name=volume,proto3" json:"volume,omitempty"`
+	LastUpdate int64   `protobuf:"varint,4,opt,name=last_update,json=lastUpdate,proto3" json:"last_update,omitempty"`
+	Outlier    bool    `protobuf:"varint,5,opt,name=outlier,proto3" json:"outlier,omitempty"`
+}
+
+func (x *ConsensusSource) Reset() {
+	*x = ConsensusSource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_pb_ccd_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConsensusSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsensusSource) ProtoMessage() {}
+
+func (x *ConsensusSource) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_pb_ccd_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsensusSource.ProtoReflect.Descriptor instead.
+func (*ConsensusSource) Descriptor() ([]byte, []int) {
+	return file_rpc_pb_ccd_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ConsensusSource) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *ConsensusSource) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *ConsensusSource) GetVolume() float64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+func (x *ConsensusSource) GetLastUpdate() int64 {
+	if x != nil {
+		return x.LastUpdate
+	}
+	return 0
+}
+
+func (x *ConsensusSource) GetOutlier() bool {
+	if x != nil {
+		return x.Outlier
+	}
+	return false
+}
+
+// Consensus volume weighted price of the data providers
+type Consensus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromSym string             `protobuf:"bytes,1,opt,name=from_sym,json=fromSym,proto3" json:"from_sym,omitempty"`
+	ToSym   string             `protobuf:"bytes,2,opt,name=to_sym,json=toSym,proto3" json:"to_sym,omitempty"`
+	Price   float64            `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	Sources []*ConsensusSource `protobuf:"bytes,4,rep,name=sources,proto3" json:"sources,omitempty"`
+}
+
+func (x *Consensus) Reset() {
+	*x = Consensus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_pb_ccd_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Consensus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Consensus) ProtoMessage() {}
+
+func (x *Consensus) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_pb_ccd_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Consensus.ProtoReflect.Descriptor instead.
+func (*Consensus) Descriptor() ([]byte, []int) {
+	return file_rpc_pb_ccd_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Consensus) GetFromSym() string {
+	if x != nil {
+		return x.FromSym
+	}
+	return ""
+}
+
+func (x *Consensus) GetToSym() string {
+	if x != nil {
+		return x.ToSym
+	}
+	return ""
+}
+
+func (x *Consensus) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Consensus) GetSources() []*ConsensusSource {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+// PriceReply with the last price and its source, or with the consensus price when the consensus mode selected
+type PriceReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data      *Data        `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Meta      *PriceSource `protobuf:"bytes,2,opt,name=meta,proto3" json:"meta,omitempty"`
+	Consensus *Consensus   `protobuf:"bytes,3,opt,name=consensus,proto3" json:"consensus,omitempty"`
+}
+
+func (x *PriceReply) Reset() {
+	*x = PriceReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_pb_ccd_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PriceReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceReply) ProtoMessage() {}
+
+func (x *PriceReply) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_pb_ccd_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceReply.ProtoReflect.Descriptor instead.
+func (*PriceReply) Descriptor() ([]byte, []int) {
+	return file_rpc_pb_ccd_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PriceReply) GetData() *Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *PriceReply) GetMeta() *PriceSource {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *PriceReply) GetConsensus() *Consensus {
+	if x != nil {
+		return x.Consensus
+	}
+	return nil
+}
+
+// Task collects data of the currencies pair with the interval in seconds
+type Task struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From     string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To       string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Provider string `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
+	Interval int64  `protobuf:"varint,4,opt,name=interval,proto3" json:"interval,omitempty"`
+}
+
+func (x *Task) Reset() {
+	*x = Task{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_pb_ccd_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Task) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task) ProtoMessage() {}
+
+func (x *Task) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_pb_ccd_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Task.ProtoReflect.Descriptor instead.
+func (*Task) Descriptor() ([]byte, []int) {
+	return file_rpc_pb_ccd_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Task) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Task) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *Task) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Task) GetInterval() int64 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+// TaskList reply with the running pull tasks
+type TaskList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tasks []*Task `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+}
+
+func (x *TaskList) Reset() {
+	*x = TaskList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_pb_ccd_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaskList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskList) ProtoMessage() {}
+
+func (x *TaskList) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_pb_ccd_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskList.ProtoReflect.Descriptor instead.
+func (*TaskList) Descriptor() ([]byte, []int) {
+	return file_rpc_pb_ccd_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TaskList) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+// Reply with the result message of the method
+type Reply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Msg string `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (x *Reply) Reset() {
+	*x = Reply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_pb_ccd_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Reply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reply) ProtoMessage() {}
+
+func (x *Reply) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_pb_ccd_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reply.ProtoReflect.Descriptor instead.
+func (*Reply) Descriptor() ([]byte, []int) {
+	return file_rpc_pb_ccd_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Reply) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+var File_rpc_pb_ccd_proto protoreflect.FileDescriptor
+
+var file_rpc_pb_ccd_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x62, 0x2f, 0x63, 0x63, 0x64, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x06, 0x63, 0x63, 0x64, 0x2e, 0x76, 0x31, 0x22, 0x4a, 0x0a, 0x0c, 0x50, 0x72,
+	0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x73,
+	0x79, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x73, 0x79, 0x6d, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x73, 0x79, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x73,
+	0x79, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x22, 0x6d, 0x0a, 0x0b, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x73, 0x79, 0x6d, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x73, 0x79, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x73, 0x79,
+	0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x73, 0x79, 0x6d, 0x12, 0x1a, 0x0a,
+	0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0x25, 0x0a, 0x0d, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x69, 0x72, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x70, 0x61, 0x69, 0x72, 0x73, 0x22, 0x07, 0x0a, 0x05,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0xd3, 0x03, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x61, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x19,
+	0x0a, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x73, 0x79, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x66, 0x72, 0x6f, 0x6d, 0x53, 0x79, 0x6d, 0x12, 0x15, 0x0a, 0x06, 0x74, 0x6f, 0x5f,
+	0x73, 0x79, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x53, 0x79, 0x6d,
+	0x12, 0x24, 0x0a, 0x0e, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x32, 0x34, 0x5f, 0x68, 0x6f,
+	0x75, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x32, 0x34, 0x48, 0x6f, 0x75, 0x72, 0x12, 0x2b, 0x0a, 0x12, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x5f, 0x70, 0x63, 0x74, 0x5f, 0x32, 0x34, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x63, 0x74, 0x32, 0x34, 0x48,
+	0x6f, 0x75, 0x72, 0x12, 0x20, 0x0a, 0x0c, 0x6f, 0x70, 0x65, 0x6e, 0x5f, 0x32, 0x34, 0x5f, 0x68,
+	0x6f, 0x75, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x6f, 0x70, 0x65, 0x6e, 0x32,
+	0x34, 0x48, 0x6f, 0x75, 0x72, 0x12, 0x24, 0x0a, 0x0e, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f,
+	0x32, 0x34, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x76,
+	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x32, 0x34, 0x48, 0x6f, 0x75, 0x72, 0x12, 0x1e, 0x0a, 0x0b, 0x6c,
+	0x6f, 0x77, 0x5f, 0x32, 0x34, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x09, 0x6c, 0x6f, 0x77, 0x32, 0x34, 0x48, 0x6f, 0x75, 0x72, 0x12, 0x20, 0x0a, 0x0c, 0x68,
+	0x69, 0x67, 0x68, 0x5f, 0x32, 0x34, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0a, 0x68, 0x69, 0x67, 0x68, 0x32, 0x34, 0x48, 0x6f, 0x75, 0x72, 0x12, 0x14, 0x0a,
+	0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x79, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x06, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x79, 0x12, 0x17, 0x0a, 0x07, 0x6d,
+	0x6b, 0x74, 0x5f, 0x63, 0x61, 0x70, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x6d, 0x6b,
+	0x74, 0x43, 0x61, 0x70, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79,
+	0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x72, 0x61, 0x77, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0e, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x44, 0x61, 0x74, 0x61, 0x52, 0x61, 0x77, 0x12,
+	0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x22, 0x37, 0x0a, 0x0b, 0x50,
+	0x72, 0x69, 0x63, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x03, 0x61, 0x67, 0x65, 0x22, 0x96, 0x01, 0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73,
+	0x75, 0x73, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x76,
+	0x69, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x76,
+	0x69, 0x64, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x6c, 0x69, 0x65, 0x72, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x6c, 0x69, 0x65, 0x72, 0x22, 0x86, 0x01,
+	0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x66,
+	0x72, 0x6f, 0x6d, 0x5f, 0x73, 0x79, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x66,
+	0x72, 0x6f, 0x6d, 0x53, 0x79, 0x6d, 0x12, 0x15, 0x0a, 0x06, 0x74, 0x6f, 0x5f, 0x73, 0x79, 0x6d,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x53, 0x79, 0x6d, 0x12, 0x14, 0x0a,
+	0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x12, 0x31, 0x0a, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x63, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
+	0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x07, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x22, 0x88, 0x01, 0x0a, 0x0a, 0x50, 0x72, 0x69, 0x63, 0x65,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x20, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x63, 0x63, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x74,
+	0x61, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x27, 0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x63, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x72, 0x69, 0x63, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x04, 0x6d, 0x65, 0x74, 0x61,
+	0x12, 0x2f, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x63, 0x63, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e,
+	0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75,
+	0x73, 0x22, 0x62, 0x0a, 0x04, 0x54, 0x61, 0x73, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f,
+	0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a,
+	0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x1a, 0x0a,
+	0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0x2e, 0x0a, 0x08, 0x54, 0x61, 0x73, 0x6b, 0x4c, 0x69, 0x73,
+	0x74, 0x12, 0x22, 0x0a, 0x05, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0c, 0x2e, 0x63, 0x63, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x05,
+	0x74, 0x61, 0x73, 0x6b, 0x73, 0x22, 0x19, 0x0a, 0x05, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67,
+	0x32, 0x95, 0x03, 0x0a, 0x03, 0x43, 0x63, 0x64, 0x12, 0x34, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x50,
+	0x72, 0x69, 0x63, 0x65, 0x12, 0x14, 0x2e, 0x63, 0x63, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72,
+	0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x63, 0x63, 0x64,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2c,
+	0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x0d, 0x2e, 0x63, 0x63,
+	0x64, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x63, 0x63, 0x64,
+	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x07,
+	0x41, 0x64, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x12, 0x13, 0x2e, 0x63, 0x63, 0x64, 0x2e, 0x76, 0x31,
+	0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x63,
+	0x63, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x12, 0x2f, 0x0a, 0x0a, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x54, 0x61, 0x73, 0x6b, 0x12, 0x13, 0x2e, 0x63, 0x63, 0x64, 0x2e, 0x76,
+	0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e,
+	0x63, 0x63, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x12, 0x30, 0x0a, 0x0a, 0x52,
+	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x73, 0x6b, 0x12, 0x13, 0x2e, 0x63, 0x63, 0x64, 0x2e,
+	0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d,
+	0x2e, 0x63, 0x63, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2f, 0x0a,
+	0x09, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x13, 0x2e, 0x63, 0x63, 0x64,
+	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x0d, 0x2e, 0x63, 0x63, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x31,
+	0x0a, 0x0b, 0x55, 0x6e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x13, 0x2e,
+	0x63, 0x63, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x63, 0x63, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x35, 0x0a, 0x0c, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x50, 0x72, 0x69, 0x63, 0x65,
+	0x73, 0x12, 0x15, 0x2e, 0x63, 0x63, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x63, 0x63, 0x64, 0x2e, 0x76,
+	0x31, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x30, 0x01, 0x42, 0x20, 0x5a, 0x1e, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x64, 0x70, 0x2f,
+	0x63, 0x63, 0x64, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_rpc_pb_ccd_proto_rawDescOnce sync.Once
+	file_rpc_pb_ccd_proto_rawDescData = file_rpc_pb_ccd_proto_rawDesc
+)
+
+func file_rpc_pb_ccd_proto_rawDescGZIP() []byte {
+	file_rpc_pb_ccd_proto_rawDescOnce.Do(func() {
+		file_rpc_pb_ccd_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_pb_ccd_proto_rawDescData)
+	})
+	return file_rpc_pb_ccd_proto_rawDescData
+}
+
+var file_rpc_pb_ccd_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_rpc_pb_ccd_proto_goTypes = []interface{}{
+	(*PriceRequest)(nil),    // 0: ccd.v1.PriceRequest
+	(*TaskRequest)(nil),     // 1: ccd.v1.TaskRequest
+	(*StreamRequest)(nil),   // 2: ccd.v1.StreamRequest
+	(*Empty)(nil),           // 3: ccd.v1.Empty
+	(*Data)(nil),            // 4: ccd.v1.Data
+	(*PriceSource)(nil),     // 5: ccd.v1.PriceSource
+	(*ConsensusSource)(nil), // 6: ccd.v1.ConsensusSource
+	(*Consensus)(nil),       // 7: ccd.v1.Consensus
+	(*PriceReply)(nil),      // 8: ccd.v1.PriceReply
+	(*Task)(nil),            // 9: ccd.v1.Task
+	(*TaskList)(nil),        // 10: ccd.v1.TaskList
+	(*Reply)(nil),           // 11: ccd.v1.Reply
+}
+var file_rpc_pb_ccd_proto_depIdxs = []int32{
+	6,  // 0: ccd.v1.Consensus.sources:type_name -> ccd.v1.ConsensusSource
+	4,  // 1: ccd.v1.PriceReply.data:type_name -> ccd.v1.Data
+	5,  // 2: ccd.v1.PriceReply.meta:type_name -> ccd.v1.PriceSource
+	7,  // 3: ccd.v1.PriceReply.consensus:type_name -> ccd.v1.Consensus
+	9,  // 4: ccd.v1.TaskList.tasks:type_name -> ccd.v1.Task
+	0,  // 5: ccd.v1.Ccd.GetPrice:input_type -> ccd.v1.PriceRequest
+	3,  // 6: ccd.v1.Ccd.ListTasks:input_type -> ccd.v1.Empty
+	1,  // 7: ccd.v1.Ccd.AddTask:input_type -> ccd.v1.TaskRequest
+	1,  // 8: ccd.v1.Ccd.UpdateTask:input_type -> ccd.v1.TaskRequest
+	1,  // 9: ccd.v1.Ccd.RemoveTask:input_type -> ccd.v1.TaskRequest
+	1,  // 10: ccd.v1.Ccd.Subscribe:input_type -> ccd.v1.TaskRequest
+	1,  // 11: ccd.v1.Ccd.Unsubscribe:input_type -> ccd.v1.TaskRequest
+	2,  // 12: ccd.v1.Ccd.StreamPrices:input_type -> ccd.v1.StreamRequest
+	8,  // 13: ccd.v1.Ccd.GetPrice:output_type -> ccd.v1.PriceReply
+	10, // 14: ccd.v1.Ccd.ListTasks:output_type -> ccd.v1.TaskList
+	9,  // 15: ccd.v1.Ccd.AddTask:output_type -> ccd.v1.Task
+	9,  // 16: ccd.v1.Ccd.UpdateTask:output_type -> ccd.v1.Task
+	11, // 17: ccd.v1.Ccd.RemoveTask:output_type -> ccd.v1.Reply
+	11, // 18: ccd.v1.Ccd.Subscribe:output_type -> ccd.v1.Reply
+	11, // 19: ccd.v1.Ccd.Unsubscribe:output_type -> ccd.v1.Reply
+	4,  // 20: ccd.v1.Ccd.StreamPrices:output_type -> ccd.v1.Data
+	13, // [13:21] is the sub-list for method output_type
+	5,  // [5:13] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_rpc_pb_ccd_proto_init() }
+func file_rpc_pb_ccd_proto_init() {
+	if File_rpc_pb_ccd_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_pb_ccd_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PriceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_pb_ccd_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_pb_ccd_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_pb_ccd_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Empty); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_pb_ccd_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Data); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_pb_ccd_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PriceSource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_pb_ccd_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConsensusSource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_pb_ccd_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Consensus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_pb_ccd_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PriceReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_pb_ccd_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Task); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_pb_ccd_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_pb_ccd_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Reply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_pb_ccd_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rpc_pb_ccd_proto_goTypes,
+		DependencyIndexes: file_rpc_pb_ccd_proto_depIdxs,
+		MessageInfos:      file_rpc_pb_ccd_proto_msgTypes,
+	}.Build()
+	File_rpc_pb_ccd_proto = out.File
+	file_rpc_pb_ccd_proto_rawDesc = nil
+	file_rpc_pb_ccd_proto_goTypes = nil
+	file_rpc_pb_ccd_proto_depIdxs = nil
+}
@@ -0,0 +1,418 @@
+// The gRPC api of the crypto currency data service, it runs alongside the rest api and shares the same providers,
+// puller and database. Regenerate the go code after changes with:
+//
+//   protoc --go_out=. --go_opt=paths=source_relative \
+//     --go-grpc_out=. --go-grpc_opt=paths=source_relative rpc/pb/ccd.proto
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: rpc/pb/ccd.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Ccd_GetPrice_FullMethodName     = "/ccd.v1.Ccd/GetPrice"
+	Ccd_ListTasks_FullMethodName    = "/ccd.v1.Ccd/ListTasks"
+	Ccd_AddTask_FullMethodName      = "/ccd.v1.Ccd/AddTask"
+	Ccd_UpdateTask_FullMethodName   = "/ccd.v1.Ccd/UpdateTask"
+	Ccd_RemoveTask_FullMethodName   = "/ccd.v1.Ccd/RemoveTask"
+	Ccd_Subscribe_FullMethodName    = "/ccd.v1.Ccd/Subscribe"
+	Ccd_Unsubscribe_FullMethodName  = "/ccd.v1.Ccd/Unsubscribe"
+	Ccd_StreamPrices_FullMethodName = "/ccd.v1.Ccd/StreamPrices"
+)
+
+// CcdClient is the client API for Ccd service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CcdClient interface {
+	// GetPrice return up-to-date or most recent data for the selected currencies pair, or the consensus price
+	GetPrice(ctx context.Context, in *PriceRequest, opts ...grpc.CallOption) (*PriceReply, error)
+	// ListTasks return running pull tasks
+	ListTasks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TaskList, error)
+	// AddTask that will collect data for the selected currencies pair, or return the running one
+	AddTask(ctx context.Context, in *TaskRequest, opts ...grpc.CallOption) (*Task, error)
+	// UpdateTask pulling data interval for the selected currencies pair
+	UpdateTask(ctx context.Context, in *TaskRequest, opts ...grpc.CallOption) (*Task, error)
+	// RemoveTask and stop collecting data for the selected currencies pair
+	RemoveTask(ctx context.Context, in *TaskRequest, opts ...grpc.CallOption) (*Reply, error)
+	// Subscribe to the ws channel of the selected (or primary) data provider to collect data for the currencies pair
+	Subscribe(ctx context.Context, in *TaskRequest, opts ...grpc.CallOption) (*Reply, error)
+	// Unsubscribe from the ws channel of the selected (or primary) data provider
+	Unsubscribe(ctx context.Context, in *TaskRequest, opts ...grpc.CallOption) (*Reply, error)
+	// StreamPrices sends the live data of the selected currencies pairs until the client cancels the stream
+	StreamPrices(ctx context.Context, in *StreamRequest, opts ...grpc.CallOption) (Ccd_StreamPricesClient, error)
+}
+
+type ccdClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCcdClient(cc grpc.ClientConnInterface) CcdClient {
+	return &ccdClient{cc}
+}
+
+func (c *ccdClient) GetPrice(ctx context.Context, in *PriceRequest, opts ...grpc.CallOption) (*PriceReply, error) {
+	out := new(PriceReply)
+	err := c.cc.Invoke(ctx, Ccd_GetPrice_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ccdClient) ListTasks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TaskList, error) {
+	out := new(TaskList)
+	err := c.cc.Invoke(ctx, Ccd_ListTasks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ccdClient) AddTask(ctx context.Context, in *TaskRequest, opts ...grpc.CallOption) (*Task, error) {
+	out := new(Task)
+	err := c.cc.Invoke(ctx, Ccd_AddTask_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ccdClient) UpdateTask(ctx context.Context, in *TaskRequest, opts ...grpc.CallOption) (*Task, error) {
+	out := new(Task)
+	err := c.cc.Invoke(ctx, Ccd_UpdateTask_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ccdClient) RemoveTask(ctx context.Context, in *TaskRequest, opts ...grpc.CallOption) (*Reply, error) {
+	out := new(Reply)
+	err := c.cc.Invoke(ctx, Ccd_RemoveTask_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ccdClient) Subscribe(ctx context.Context, in *TaskRequest, opts ...grpc.CallOption) (*Reply, error) {
+	out := new(Reply)
+	err := c.cc.Invoke(ctx, Ccd_Subscribe_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ccdClient) Unsubscribe(ctx context.Context, in *TaskRequest, opts ...grpc.CallOption) (*Reply, error) {
+	out := new(Reply)
+	err := c.cc.Invoke(ctx, Ccd_Unsubscribe_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ccdClient) StreamPrices(ctx context.Context, in *StreamRequest, opts ...grpc.CallOption) (Ccd_StreamPricesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Ccd_ServiceDesc.Streams[0], Ccd_StreamPrices_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &ccdStreamPricesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Ccd_StreamPricesClient interface {
+	Recv() (*Data, error)
+	grpc.ClientStream
+}
+
+type ccdStreamPricesClient struct {
+	grpc.ClientStream
+}
+
+func (x *ccdStreamPricesClient) Recv() (*Data, error) {
+	m := new(Data)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// CcdServer is the server API for Ccd service.
+// All implementations must embed UnimplementedCcdServer
+// for forward compatibility
+type CcdServer interface {
+	// GetPrice return up-to-date or most recent data for the selected currencies pair, or the consensus price
+	GetPrice(context.Context, *PriceRequest) (*PriceReply, error)
+	// ListTasks return running pull tasks
+	ListTasks(context.Context, *Empty) (*TaskList, error)
+	// AddTask that will collect data for the selected currencies pair, or return the running one
+	AddTask(context.Context, *TaskRequest) (*Task, error)
+	// UpdateTask pulling data interval for the selected currencies pair
+	UpdateTask(context.Context, *TaskRequest) (*Task, error)
+	// RemoveTask and stop collecting data for the selected currencies pair
+	RemoveTask(context.Context, *TaskRequest) (*Reply, error)
+	// Subscribe to the ws channel of the selected (or primary) data provider to collect data for the currencies pair
+	Subscribe(context.Context, *TaskRequest) (*Reply, error)
+	// Unsubscribe from the ws channel of the selected (or primary) data provider
+	Unsubscribe(context.Context, *TaskRequest) (*Reply, error)
+	// StreamPrices sends the live data of the selected currencies pairs until the client cancels the stream
+	StreamPrices(*StreamRequest, Ccd_StreamPricesServer) error
+	mustEmbedUnimplementedCcdServer()
+}
+
+// UnimplementedCcdServer must be embedded to have forward compatible implementations.
+type UnimplementedCcdServer struct {
+}
+
+func (UnimplementedCcdServer) GetPrice(context.Context, *PriceRequest) (*PriceReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPrice not implemented")
+}
+func (UnimplementedCcdServer) ListTasks(context.Context, *Empty) (*TaskList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTasks not implemented")
+}
+func (UnimplementedCcdServer) AddTask(context.Context, *TaskRequest) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTask not implemented")
+}
+func (UnimplementedCcdServer) UpdateTask(context.Context, *TaskRequest) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTask not implemented")
+}
+func (UnimplementedCcdServer) RemoveTask(context.Context, *TaskRequest) (*Reply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTask not implemented")
+}
+func (UnimplementedCcdServer) Subscribe(context.Context, *TaskRequest) (*Reply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedCcdServer) Unsubscribe(context.Context, *TaskRequest) (*Reply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unsubscribe not implemented")
+}
+func (UnimplementedCcdServer) StreamPrices(*StreamRequest, Ccd_StreamPricesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamPrices not implemented")
+}
+func (UnimplementedCcdServer) mustEmbedUnimplementedCcdServer() {}
+
+// UnsafeCcdServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CcdServer will
+// result in compilation errors.
+type UnsafeCcdServer interface {
+	mustEmbedUnimplementedCcdServer()
+}
+
+func RegisterCcdServer(s grpc.ServiceRegistrar, srv CcdServer) {
+	s.RegisterService(&Ccd_ServiceDesc, srv)
+}
+
+func _Ccd_GetPrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PriceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CcdServer).GetPrice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Ccd_GetPrice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CcdServer).GetPrice(ctx, req.(*PriceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ccd_ListTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CcdServer).ListTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Ccd_ListTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CcdServer).ListTasks(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ccd_AddTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CcdServer).AddTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Ccd_AddTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CcdServer).AddTask(ctx, req.(*TaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ccd_UpdateTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CcdServer).UpdateTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Ccd_UpdateTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CcdServer).UpdateTask(ctx, req.(*TaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ccd_RemoveTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CcdServer).RemoveTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Ccd_RemoveTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CcdServer).RemoveTask(ctx, req.(*TaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ccd_Subscribe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CcdServer).Subscribe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Ccd_Subscribe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CcdServer).Subscribe(ctx, req.(*TaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ccd_Unsubscribe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CcdServer).Unsubscribe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Ccd_Unsubscribe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CcdServer).Unsubscribe(ctx, req.(*TaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ccd_StreamPrices_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CcdServer).StreamPrices(m, &ccdStreamPricesServer{stream})
+}
+
+type Ccd_StreamPricesServer interface {
+	Send(*Data) error
+	grpc.ServerStream
+}
+
+type ccdStreamPricesServer struct {
+	grpc.ServerStream
+}
+
+func (x *ccdStreamPricesServer) Send(m *Data) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Ccd_ServiceDesc is the grpc.ServiceDesc for Ccd service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Ccd_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ccd.v1.Ccd",
+	HandlerType: (*CcdServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPrice",
+			Handler:    _Ccd_GetPrice_Handler,
+		},
+		{
+			MethodName: "ListTasks",
+			Handler:    _Ccd_ListTasks_Handler,
+		},
+		{
+			MethodName: "AddTask",
+			Handler:    _Ccd_AddTask_Handler,
+		},
+		{
+			MethodName: "UpdateTask",
+			Handler:    _Ccd_UpdateTask_Handler,
+		},
+		{
+			MethodName: "RemoveTask",
+			Handler:    _Ccd_RemoveTask_Handler,
+		},
+		{
+			MethodName: "Subscribe",
+			Handler:    _Ccd_Subscribe_Handler,
+		},
+		{
+			MethodName: "Unsubscribe",
+			Handler:    _Ccd_Unsubscribe_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamPrices",
+			Handler:       _Ccd_StreamPrices_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "rpc/pb/ccd.proto",
+}
@@ -0,0 +1,235 @@
+package rpc
+
+import (
+	"context"
+	"log"
+	"net"
+	"strings"
+
+	"github.com/streamdp/ccd/clients"
+	"github.com/streamdp/ccd/db"
+	"github.com/streamdp/ccd/domain"
+	"github.com/streamdp/ccd/hub"
+	"github.com/streamdp/ccd/repos"
+	v1 "github.com/streamdp/ccd/router/v1"
+	"github.com/streamdp/ccd/rpc/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const hubBufferSize = 256
+
+// Server of the gRPC api described in pb/ccd.proto, it runs alongside the rest api and shares the same providers,
+// puller and database
+type Server struct {
+	pb.UnimplementedCcdServer
+	s  *grpc.Server
+	l  *log.Logger
+	db db.Database
+	sr *repos.SymbolRepo
+	pr *clients.Providers
+	p  clients.RestApiPuller
+	h  *hub.Hub
+	// done is closed on stop to end the open price streams
+	done chan struct{}
+}
+
+// NewServer init gRPC server with the registered service
+func NewServer(
+	l *log.Logger,
+	d db.Database,
+	sr *repos.SymbolRepo,
+	pr *clients.Providers,
+	p clients.RestApiPuller,
+	h *hub.Hub,
+) *Server {
+	s := &Server{
+		s:    grpc.NewServer(),
+		l:    l,
+		db:   d,
+		sr:   sr,
+		pr:   pr,
+		p:    p,
+		h:    h,
+		done: make(chan struct{}),
+	}
+	pb.RegisterCcdServer(s.s, s)
+	return s
+}
+
+// Serve gRPC requests on the selected address, it blocks until the server stopped
+func (s *Server) Serve(addr string) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.s.Serve(lis)
+}
+
+// Stop the server gracefully, the open price streams are ended first, because they never finish by themselves. The
+// server is stopped forcibly if the running requests don't finish before the context is done.
+func (s *Server) Stop(ctx context.Context) {
+	close(s.done)
+	stopped := make(chan struct{})
+	go func() {
+		s.s.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		s.s.Stop()
+	}
+}
+
+// GetPrice return up-to-date or most recent data for the selected currencies pair, or the consensus price
+func (s *Server) GetPrice(_ context.Context, req *pb.PriceRequest) (*pb.PriceReply, error) {
+	if err := s.validatePair(req.Fsym, req.Tsym); err != nil {
+		return nil, err
+	}
+	q := &v1.PriceQuery{From: req.Fsym, To: req.Tsym, Mode: req.Mode}
+	switch req.Mode {
+	case "", "last":
+		d, source, err := v1.LastPrice(s.pr, s.db, q)
+		if err != nil {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+		return &pb.PriceReply{Data: toData(d), Meta: toPriceSource(source)}, nil
+	case "consensus":
+		c, err := v1.ConsensusPrice(s.pr, s.db, q)
+		if err != nil {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+		return &pb.PriceReply{Consensus: toConsensus(c)}, nil
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown mode %q", req.Mode)
+	}
+}
+
+// ListTasks return running pull tasks
+func (s *Server) ListTasks(_ context.Context, _ *pb.Empty) (*pb.TaskList, error) {
+	list := &pb.TaskList{}
+	for _, t := range s.p.ListTasks() {
+		list.Tasks = append(list.Tasks, toTask(t))
+	}
+	return list, nil
+}
+
+// AddTask that will collect data for the selected currencies pair, or return the running one
+func (s *Server) AddTask(_ context.Context, req *pb.TaskRequest) (*pb.Task, error) {
+	if err := s.validateTask(req); err != nil {
+		return nil, err
+	}
+	if t := s.p.Task(req.Fsym, req.Tsym, req.Provider); t != nil {
+		return toTask(t), nil
+	}
+	return toTask(s.p.AddTask(req.Fsym, req.Tsym, req.Provider, req.Interval)), nil
+}
+
+// UpdateTask pulling data interval for the selected currencies pair
+func (s *Server) UpdateTask(_ context.Context, req *pb.TaskRequest) (*pb.Task, error) {
+	if err := s.validateTask(req); err != nil {
+		return nil, err
+	}
+	t := s.p.Task(req.Fsym, req.Tsym, req.Provider)
+	if t == nil {
+		return nil, status.Error(codes.NotFound, "no data is collected for this pair")
+	}
+	return toTask(s.p.UpdateTask(t, req.Interval)), nil
+}
+
+// RemoveTask and stop collecting data for the selected currencies pair
+func (s *Server) RemoveTask(_ context.Context, req *pb.TaskRequest) (*pb.Reply, error) {
+	if err := s.validateTask(req); err != nil {
+		return nil, err
+	}
+	if s.p.Task(req.Fsym, req.Tsym, req.Provider) == nil {
+		return nil, status.Error(codes.NotFound, "no data is collected for this pair")
+	}
+	s.p.RemoveTask(req.Fsym, req.Tsym, req.Provider)
+	return &pb.Reply{Msg: "Task stopped successfully"}, nil
+}
+
+// Subscribe to the ws channel of the selected (or primary) data provider to collect data for the currencies pair
+func (s *Server) Subscribe(_ context.Context, req *pb.TaskRequest) (*pb.Reply, error) {
+	w, err := s.wsClient(req)
+	if err != nil {
+		return nil, err
+	}
+	if err = w.Subscribe(req.Fsym, req.Tsym); err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	return &pb.Reply{Msg: "Subscribed successfully, data collection started"}, nil
+}
+
+// Unsubscribe from the ws channel of the selected (or primary) data provider
+func (s *Server) Unsubscribe(_ context.Context, req *pb.TaskRequest) (*pb.Reply, error) {
+	w, err := s.wsClient(req)
+	if err != nil {
+		return nil, err
+	}
+	if err = w.Unsubscribe(req.Fsym, req.Tsym); err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	return &pb.Reply{Msg: "Unsubscribed successfully, data collection stopped"}, nil
+}
+
+// StreamPrices sends the live data of the selected currencies pairs until the client cancels the stream
+func (s *Server) StreamPrices(req *pb.StreamRequest, stream pb.Ccd_StreamPricesServer) error {
+	if len(req.Pairs) == 0 {
+		return status.Error(codes.InvalidArgument, "pairs should be specified like [\"BTC:USD\"]")
+	}
+	sub := s.h.Subscribe(hubBufferSize)
+	defer s.h.Unsubscribe(sub)
+	for _, name := range req.Pairs {
+		parts := strings.Split(name, ":")
+		if len(parts) != 2 {
+			return status.Errorf(codes.InvalidArgument, "wrong currencies pair %q", name)
+		}
+		if err := s.validatePair(parts[0], parts[1]); err != nil {
+			return err
+		}
+		sub.Add(parts[0], parts[1])
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-s.done:
+			return status.Error(codes.Unavailable, "server is shutting down")
+		case e, ok := <-sub.C():
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(toData(e.Data)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *Server) wsClient(req *pb.TaskRequest) (clients.WsClient, error) {
+	if err := s.validateTask(req); err != nil {
+		return nil, err
+	}
+	w := s.pr.Ws(req.Provider)
+	if w == nil {
+		return nil, status.Error(codes.Unimplemented, "selected data provider doesn't support websocket")
+	}
+	return w, nil
+}
+
+func (s *Server) validateTask(req *pb.TaskRequest) error {
+	if req.Provider != "" && !s.pr.IsPresent(req.Provider) {
+		return status.Errorf(codes.InvalidArgument, "unknown data provider %q", req.Provider)
+	}
+	return s.validatePair(req.Fsym, req.Tsym)
+}
+
+func (s *Server) validatePair(from, to string) error {
+	if !s.sr.IsPresent(from) || !s.sr.IsPresent(to) {
+		return status.Errorf(codes.InvalidArgument, "wrong currencies pair %q", domain.PairName(from, to))
+	}
+	return nil
+}
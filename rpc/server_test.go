@@ -0,0 +1,195 @@
+package rpc
+
+import (
+	"context"
+	"io"
+	"log"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/streamdp/ccd/clients"
+	"github.com/streamdp/ccd/clients/recorder"
+	"github.com/streamdp/ccd/db/memory"
+	"github.com/streamdp/ccd/domain"
+	"github.com/streamdp/ccd/hub"
+	"github.com/streamdp/ccd/repos"
+	"github.com/streamdp/ccd/rpc/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type testRest struct{}
+
+func (testRest) Get(from, to string) (*domain.Data, error) {
+	return &domain.Data{FromSymbol: from, ToSymbol: to, Price: 43183.4, LastUpdate: time.Now().UnixMilli(),
+		Provider: "test"}, nil
+}
+
+// newTestClient serves the server on the in-memory listener and return the client connected to it
+func newTestClient(t *testing.T) (pb.CcdClient, *hub.Hub, *Server) {
+	l := log.New(io.Discard, "", 0)
+	d, h := memory.New(), hub.New()
+	go func() {
+		for range d.DataPipe() {
+		}
+	}()
+	sr := repos.NewSymbolRepository(d)
+	if err := sr.Load(); err != nil {
+		t.Fatal(err)
+	}
+	r := clients.NewRegistry(nil)
+	r.Register("test", func(*recorder.Recorder) (clients.RestClient, error) { return testRest{}, nil }, nil)
+	pr, err := r.Build([]string{"test"}, d.DataPipe(), l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	session, err := repos.NewSessionRepo(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewServer(l, d, sr, pr, clients.NewPuller(pr, l, session, d.DataPipe()), h)
+	lis := bufconn.Listen(1 << 20)
+	go func() {
+		_ = s.s.Serve(lis)
+	}()
+	conn, err := grpc.Dial("bufnet", grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+		select {
+		case <-s.done:
+		default:
+			s.Stop(context.Background())
+		}
+	})
+	return pb.NewCcdClient(conn), h, s
+}
+
+func TestGetPrice(t *testing.T) {
+	c, _, _ := newTestClient(t)
+	ctx := context.Background()
+	reply, err := c.GetPrice(ctx, &pb.PriceRequest{Fsym: "BTC", Tsym: "USD"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.Data.GetPrice() != 43183.4 || reply.Data.GetFromSym() != "BTC" || reply.Meta.GetSource() != "test" {
+		t.Errorf("got unexpected reply %v", reply)
+	}
+	reply, err = c.GetPrice(ctx, &pb.PriceRequest{Fsym: "BTC", Tsym: "USD", Mode: "consensus"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.Consensus.GetPrice() != 43183.4 || len(reply.Consensus.GetSources()) != 1 {
+		t.Errorf("got unexpected consensus %v", reply.Consensus)
+	}
+	if _, err = c.GetPrice(ctx, &pb.PriceRequest{Fsym: "XXX", Tsym: "USD"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("got error %v, want invalid argument", err)
+	}
+}
+
+func TestTasks(t *testing.T) {
+	c, _, _ := newTestClient(t)
+	ctx := context.Background()
+	task, err := c.AddTask(ctx, &pb.TaskRequest{Fsym: "BTC", Tsym: "USD", Interval: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.From != "BTC" || task.To != "USD" || task.Interval != 60 {
+		t.Errorf("got unexpected task %v", task)
+	}
+	if task, err = c.UpdateTask(ctx, &pb.TaskRequest{Fsym: "BTC", Tsym: "USD", Interval: 30}); err != nil ||
+		task.Interval != 30 {
+		t.Errorf("got task %v (%v), want interval 30", task, err)
+	}
+	list, err := c.ListTasks(ctx, &pb.Empty{})
+	if err != nil || len(list.Tasks) != 1 {
+		t.Errorf("got tasks %v (%v), want 1 task", list, err)
+	}
+	if _, err = c.RemoveTask(ctx, &pb.TaskRequest{Fsym: "BTC", Tsym: "USD"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.RemoveTask(ctx, &pb.TaskRequest{Fsym: "BTC", Tsym: "USD"}); status.Code(err) != codes.NotFound {
+		t.Errorf("got error %v, want not found", err)
+	}
+	if _, err = c.Subscribe(ctx, &pb.TaskRequest{Fsym: "BTC", Tsym: "USD"}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("got error %v, want unimplemented", err)
+	}
+}
+
+func TestStreamPrices(t *testing.T) {
+	c, h, _ := newTestClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := c.StreamPrices(ctx, &pb.StreamRequest{Pairs: []string{"BTC:USD"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		// the server subscribes after the stream is opened, publish until the client got the data
+		for ctx.Err() == nil {
+			h.Publish(&domain.Data{FromSymbol: "ETH", ToSymbol: "USD", Price: 1})
+			h.Publish(&domain.Data{FromSymbol: "BTC", ToSymbol: "USD", Price: 2})
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+	d, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.FromSym != "BTC" || d.Price != 2 {
+		t.Errorf("got unexpected data %v", d)
+	}
+}
+
+func TestStopWithOpenStream(t *testing.T) {
+	c, h, s := newTestClient(t)
+	stream, err := c.StreamPrices(context.Background(), &pb.StreamRequest{Pairs: []string{"BTC:USD"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// wait until the server handles the stream
+	received := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-received:
+				return
+			default:
+				h.Publish(&domain.Data{FromSymbol: "BTC", ToSymbol: "USD", Price: 2})
+				time.Sleep(10 * time.Millisecond)
+			}
+		}
+	}()
+	_, err = stream.Recv()
+	close(received)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stopped := make(chan struct{})
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		s.Stop(ctx)
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("stop hangs on the open stream")
+	}
+	for err == nil {
+		_, err = stream.Recv() // the data published before the stop could be received first
+	}
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("got error %v, want unavailable", err)
+	}
+}
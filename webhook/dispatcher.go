@@ -0,0 +1,289 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/streamdp/ccd/config"
+	"github.com/streamdp/ccd/db"
+	"github.com/streamdp/ccd/domain"
+	"github.com/streamdp/ccd/hub"
+)
+
+const (
+	hubBufferSize = 4096
+	queueSize     = 1024
+	maxBatchSize  = 500
+	// maxFailures of the batch deliveries in a row before the webhook is disabled
+	maxFailures = 5
+)
+
+// ErrNotFound is returned when there is no webhook with the selected id
+var ErrNotFound = errors.New("webhook not found")
+
+// Dispatcher posts signed json batches of the ticks published to the hub to the registered webhooks
+type Dispatcher struct {
+	db        db.Database
+	l         *log.Logger
+	h         *hub.Hub
+	s         *Sender
+	endpoints map[int64]*endpoint
+	mu        sync.Mutex
+}
+
+// endpoint is the running delivery loop of the webhook with its bounded queue, the oldest ticks are dropped when the
+// receiver doesn't keep up
+type endpoint struct {
+	w       *domain.Webhook
+	pairs   map[string]struct{}
+	queue   chan *domain.Data
+	dropped int64
+	ctx     context.Context
+	cancel  context.CancelFunc
+}
+
+// NewDispatcher init dispatcher with the webhooks stored in the database
+func NewDispatcher(d db.Database, l *log.Logger, h *hub.Hub) (*Dispatcher, error) {
+	webhooks, err := d.Webhooks()
+	if err != nil {
+		return nil, err
+	}
+	ds := &Dispatcher{
+		db:        d,
+		l:         l,
+		h:         h,
+		s:         NewSender(time.Duration(config.HttpClientTimeout) * time.Millisecond),
+		endpoints: make(map[int64]*endpoint, len(webhooks)),
+	}
+	for _, w := range webhooks {
+		ds.start(w)
+	}
+	return ds, nil
+}
+
+// Run dispatching of the ticks in the background
+func (ds *Dispatcher) Run() {
+	sub := ds.h.SubscribeAll(hubBufferSize)
+	go func() {
+		for e := range sub.C() {
+			ds.dispatch(e.Data)
+		}
+	}()
+}
+
+// List all webhooks ordered by id
+func (ds *Dispatcher) List() []*domain.Webhook {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	webhooks := make([]*domain.Webhook, 0, len(ds.endpoints))
+	for _, e := range ds.endpoints {
+		webhooks = append(webhooks, e.webhook())
+	}
+	sort.Slice(webhooks, func(i, j int) bool {
+		return webhooks[i].Id < webhooks[j].Id
+	})
+	return webhooks
+}
+
+// Get webhook with the selected id
+func (ds *Dispatcher) Get(id int64) (*domain.Webhook, error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	e, ok := ds.endpoints[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return e.webhook(), nil
+}
+
+// Add new webhook and start delivering ticks to it
+func (ds *Dispatcher) Add(w *domain.Webhook) (err error) {
+	if w.Id, err = ds.db.AddWebhook(w); err != nil {
+		return
+	}
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	ds.start(w)
+	return
+}
+
+// Update the webhook, its queue is dropped and the failures counter is reset
+func (ds *Dispatcher) Update(w *domain.Webhook) (err error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	e, ok := ds.endpoints[w.Id]
+	if !ok {
+		return ErrNotFound
+	}
+	w.Failures = 0
+	if _, err = ds.db.UpdateWebhook(w); err != nil {
+		return
+	}
+	e.cancel()
+	ds.start(w)
+	return
+}
+
+// Remove the webhook with the selected id
+func (ds *Dispatcher) Remove(id int64) (err error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	e, ok := ds.endpoints[id]
+	if !ok {
+		return ErrNotFound
+	}
+	if _, err = ds.db.RemoveWebhook(id); err != nil {
+		return
+	}
+	e.cancel()
+	delete(ds.endpoints, id)
+	return
+}
+
+func (ds *Dispatcher) dispatch(d *domain.Data) {
+	pair := domain.PairName(d.FromSymbol, d.ToSymbol)
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	for _, e := range ds.endpoints {
+		if e.match(pair) {
+			e.push(d)
+		}
+	}
+}
+
+func (ds *Dispatcher) start(webhook *domain.Webhook) {
+	w := *webhook
+	e := &endpoint{
+		w:     &w,
+		pairs: make(map[string]struct{}, len(w.Pairs)),
+		queue: make(chan *domain.Data, queueSize),
+	}
+	for _, p := range w.Pairs {
+		e.pairs[p] = struct{}{}
+	}
+	e.ctx, e.cancel = context.WithCancel(context.Background())
+	ds.endpoints[w.Id] = e
+	if w.Enabled {
+		go ds.serve(e)
+	}
+}
+
+// serve collects the batch within the window of the webhook and delivers it, the oldest ticks of the batch are
+// dropped when it grows over the max batch size
+func (ds *Dispatcher) serve(e *endpoint) {
+	window := time.Duration(e.w.Window) * time.Second
+	if window <= 0 {
+		window = time.Second
+	}
+	ticker := time.NewTicker(window)
+	defer ticker.Stop()
+	batch := make([]*domain.Data, 0, maxBatchSize)
+	for {
+		select {
+		case <-e.ctx.Done():
+			return
+		case d := <-e.queue:
+			if len(batch) == maxBatchSize {
+				copy(batch, batch[1:])
+				batch = batch[:len(batch)-1]
+				atomic.AddInt64(&e.dropped, 1)
+			}
+			batch = append(batch, d)
+		case <-ticker.C:
+			if len(batch) == 0 {
+				continue
+			}
+			if !ds.deliver(e, batch) {
+				return
+			}
+			batch = batch[:0]
+		}
+	}
+}
+
+// deliver the batch and return false if the webhook was disabled after repeated failures
+func (ds *Dispatcher) deliver(e *endpoint, batch []*domain.Data) bool {
+	body, err := json.Marshal(&domain.WebhookBatch{
+		WebhookId: e.w.Id,
+		Data:      batch,
+		Dropped:   atomic.SwapInt64(&e.dropped, 0),
+		Time:      time.Now().Unix(),
+	})
+	if err != nil {
+		ds.l.Println(err)
+		return true
+	}
+	if err = ds.s.Send(e.ctx, e.w.Url, e.w.Secret, body, nil); err == nil {
+		ds.report(e, true)
+		return true
+	}
+	if e.ctx.Err() != nil {
+		return false
+	}
+	ds.l.Println(err)
+	return ds.report(e, false)
+}
+
+// report the result of the batch delivery, the webhook is disabled after too many failures in a row. It returns
+// false if the webhook was disabled.
+func (ds *Dispatcher) report(e *endpoint, delivered bool) bool {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	if e.ctx.Err() != nil {
+		return false
+	}
+	if delivered {
+		if e.w.Failures == 0 {
+			return true
+		}
+		e.w.Failures = 0
+	} else {
+		e.w.Failures++
+	}
+	if e.w.Failures >= maxFailures {
+		e.w.Enabled = false
+		ds.l.Printf("webhook %d to %s disabled after %d failed deliveries", e.w.Id, e.w.Url, e.w.Failures)
+	}
+	if _, err := ds.db.UpdateWebhook(e.w); err != nil {
+		ds.l.Println(err)
+	}
+	return e.w.Enabled
+}
+
+func (e *endpoint) webhook() *domain.Webhook {
+	w := *e.w
+	return &w
+}
+
+func (e *endpoint) match(pair string) bool {
+	if !e.w.Enabled {
+		return false
+	}
+	if len(e.pairs) == 0 {
+		return true
+	}
+	_, ok := e.pairs[pair]
+	return ok
+}
+
+// push the tick to the queue, dropping the oldest one if the queue is full
+func (e *endpoint) push(d *domain.Data) {
+	for {
+		select {
+		case e.queue <- d:
+			return
+		default:
+		}
+		select {
+		case <-e.queue:
+			atomic.AddInt64(&e.dropped, 1)
+		default:
+		}
+	}
+}
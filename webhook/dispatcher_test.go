@@ -0,0 +1,73 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/streamdp/ccd/db/memory"
+	"github.com/streamdp/ccd/domain"
+	"github.com/streamdp/ccd/hub"
+)
+
+func newTestDispatcher(t *testing.T) *Dispatcher {
+	ds, err := NewDispatcher(memory.New(), log.New(io.Discard, "", 0), hub.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ds
+}
+
+func TestUpdateRemoveNotFound(t *testing.T) {
+	ds := newTestDispatcher(t)
+	if err := ds.Update(&domain.Webhook{Id: 1, Url: "http://localhost"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("update: got error %v, want %v", err, ErrNotFound)
+	}
+	if err := ds.Remove(1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("remove: got error %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestBatchDropsOldest(t *testing.T) {
+	batches := make(chan *domain.WebhookBatch, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b := &domain.WebhookBatch{}
+		if err := json.NewDecoder(r.Body).Decode(b); err != nil {
+			t.Error(err)
+		}
+		select {
+		case batches <- b:
+		default:
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	ds := newTestDispatcher(t)
+	w := &domain.Webhook{Url: srv.URL, Window: 1, Enabled: true}
+	if err := ds.Add(w); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = ds.Remove(w.Id)
+	})
+	for i := 0; i < maxBatchSize+10; i++ {
+		ds.dispatch(&domain.Data{FromSymbol: "BTC", ToSymbol: "USD", Price: float64(i)})
+	}
+
+	select {
+	case b := <-batches:
+		if len(b.Data) != maxBatchSize || b.Dropped != 10 {
+			t.Fatalf("got %d ticks and %d dropped, want %d and 10", len(b.Data), b.Dropped, maxBatchSize)
+		}
+		if first, last := b.Data[0].Price, b.Data[len(b.Data)-1].Price; first != 10 || last != maxBatchSize+9 {
+			t.Errorf("got ticks from %v to %v, want from 10 to %d", first, last, maxBatchSize+9)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no batch delivered")
+	}
+}
@@ -0,0 +1,113 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// SignatureHeader contains "sha256=" and the hex encoded HMAC-SHA256 of the timestamp, a dot and the body
+	SignatureHeader = "X-Ccd-Signature"
+	// TimestampHeader contains the unix time of the delivery attempt, receivers should reject old ones
+	TimestampHeader = "X-Ccd-Timestamp"
+
+	defaultAttempts   = 5
+	defaultBackoff    = time.Second
+	defaultMaxBackoff = time.Minute
+)
+
+// Attempt result of the one delivery attempt, Status is zero if the receiver wasn't reached
+type Attempt struct {
+	Number int
+	Status int
+	Err    error
+}
+
+// Sender posts signed json payloads to the webhook receivers, retrying with exponential backoff
+type Sender struct {
+	client     *http.Client
+	attempts   int
+	backoff    time.Duration
+	maxBackoff time.Duration
+}
+
+// NewSender init sender with the selected request timeout
+func NewSender(timeout time.Duration) *Sender {
+	return &Sender{
+		client: &http.Client{
+			Timeout: timeout,
+		},
+		attempts:   defaultAttempts,
+		backoff:    defaultBackoff,
+		maxBackoff: defaultMaxBackoff,
+	}
+}
+
+// Sign the body with the secret, the timestamp is signed too to prevent replaying of the old deliveries
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Send the body to the url until the receiver responds with 2xx, attempts are exhausted or the context is done.
+// The report is called after every attempt, e.g. to keep the delivery log.
+func (s *Sender) Send(ctx context.Context, url, secret string, body []byte, report func(a *Attempt)) (err error) {
+	backoff := s.backoff
+	for n := 1; n <= s.attempts; n++ {
+		a := &Attempt{
+			Number: n,
+		}
+		a.Status, a.Err = s.post(ctx, url, secret, body)
+		if report != nil {
+			report(a)
+		}
+		if a.Err == nil {
+			return nil
+		}
+		err = a.Err
+		if n == s.attempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > s.maxBackoff {
+			backoff = s.maxBackoff
+		}
+	}
+	return fmt.Errorf("delivery to %s failed after %d attempts: %w", url, s.attempts, err)
+}
+
+func (s *Sender) post(ctx context.Context, url, secret string, body []byte) (status int, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	if secret != "" {
+		req.Header.Set(SignatureHeader, Sign(secret, timestamp, body))
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("receiver responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}